@@ -0,0 +1,119 @@
+package apply
+
+import (
+	"fmt"
+	"github.com/mihai-valentin/polyroll/internal/diff"
+	"github.com/mihai-valentin/polyroll/internal/graph"
+	"time"
+)
+
+type Status string
+
+const (
+	Applied Status = "applied"
+	Failed  Status = "failed"
+	Skipped Status = "skipped"
+)
+
+type Applier interface {
+	ApplyResource(r any) (diff.Action, error)
+}
+
+type ResourceResult struct {
+	Kind     string
+	Name     string
+	Status   Status
+	Action   diff.Action
+	Err      error
+	Duration time.Duration
+}
+
+type Report struct {
+	Resources []ResourceResult
+}
+
+func Run(applier Applier, resources []*graph.Node, output Output) *Report {
+	report := &Report{}
+	statuses := map[*graph.Node]Status{}
+
+	for _, node := range resources {
+		result := ResourceResult{Kind: node.Kind, Name: node.Name}
+
+		if dependency := failedDependency(node, statuses); dependency != nil {
+			result.Status = Skipped
+			result.Err = fmt.Errorf("dependency %s [%s] failed", dependency.Kind, dependency.Name)
+		} else {
+			output.Applying(node)
+
+			startedAt := time.Now()
+			result.Action, result.Err = applier.ApplyResource(node.Resource)
+			result.Duration = time.Since(startedAt)
+
+			result.Status = Applied
+			if result.Err != nil {
+				result.Status = Failed
+			}
+		}
+
+		output.Applied(result)
+
+		statuses[node] = result.Status
+		report.Resources = append(report.Resources, result)
+	}
+
+	return report
+}
+
+func failedDependency(node *graph.Node, statuses map[*graph.Node]Status) *graph.Node {
+	for _, dependency := range node.Dependencies {
+		if statuses[dependency] == Failed || statuses[dependency] == Skipped {
+			return dependency
+		}
+	}
+
+	return nil
+}
+
+func (r *Report) HasFailures() bool {
+	for _, result := range r.Resources {
+		if result.Status != Applied {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Report) Summary() map[string]int {
+	summary := map[string]int{
+		"created":   0,
+		"updated":   0,
+		"unchanged": 0,
+		"failed":    0,
+		"skipped":   0,
+	}
+
+	for _, result := range r.Resources {
+		summary[result.outcome()]++
+	}
+
+	return summary
+}
+
+func (r ResourceResult) outcome() string {
+	switch r.Status {
+	case Failed:
+		return "failed"
+	case Skipped:
+		return "skipped"
+	}
+
+	switch r.Action {
+	case diff.Create:
+		return "created"
+	case diff.Update:
+		return "updated"
+	}
+
+	return "unchanged"
+}
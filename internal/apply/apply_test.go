@@ -0,0 +1,252 @@
+package apply
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mihai-valentin/polyroll/internal/diff"
+	"github.com/mihai-valentin/polyroll/internal/elk"
+	"github.com/mihai-valentin/polyroll/internal/graph"
+	"io"
+	"log"
+	"reflect"
+	"testing"
+)
+
+type FailingWriter struct{}
+
+func (w *FailingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+type MockedApplier struct {
+	failing map[string]bool
+	applied []string
+}
+
+func (a *MockedApplier) ApplyResource(r any) (diff.Action, error) {
+	name := r.(string)
+	a.applied = append(a.applied, name)
+
+	if a.failing[name] {
+		return "", errors.New("not acknowledged")
+	}
+
+	if name == "invalid" {
+		return "", fmt.Errorf("cannot fetch current ILM policy: %w", &elk.ResponseError{
+			StatusCode: 400,
+			Type:       "illegal_argument_exception",
+			Reason:     "unknown setting",
+		})
+	}
+
+	if name == "unreachable" {
+		return "", &elk.ConnectionError{Err: errors.New("connection refused")}
+	}
+
+	return diff.Create, nil
+}
+
+func TestRun(t *testing.T) {
+	log.SetOutput(io.Discard)
+
+	g := graph.New([]string{"policy", "template", "data stream"})
+	g.Add("policy", "broken", "broken")
+	g.Add("policy", "working", "working")
+	broken := g.Add("template", "broken-template", "broken-template")
+	working := g.Add("template", "working-template", "working-template")
+	stream := g.Add("data stream", "broken-stream", "broken-stream")
+	g.AddDependency(broken, "policy", "broken")
+	g.AddDependency(working, "policy", "working")
+	g.AddDependency(stream, "template", "broken-template")
+
+	resources, err := g.Sort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applier := &MockedApplier{failing: map[string]bool{"broken": true}}
+	report := Run(applier, resources, NewTextOutput(io.Discard))
+
+	t.Run("Dependents of failed resources are not applied", func(t *testing.T) {
+		expected := []string{"broken", "working", "working-template"}
+
+		if !reflect.DeepEqual(applier.applied, expected) {
+			t.Errorf("actual %v\nwant %v", applier.applied, expected)
+		}
+	})
+
+	t.Run("Resources statuses are reported", func(t *testing.T) {
+		expected := map[string]Status{
+			"broken":           Failed,
+			"working":          Applied,
+			"broken-template":  Skipped,
+			"working-template": Applied,
+			"broken-stream":    Skipped,
+		}
+
+		for _, result := range report.Resources {
+			if result.Status != expected[result.Name] {
+				t.Errorf("[%s]: actual %v\nwant %v", result.Name, result.Status, expected[result.Name])
+			}
+		}
+
+		if !report.HasFailures() {
+			t.Error("expected report to have failures")
+		}
+	})
+
+	t.Run("Summary is printed", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := NewTextOutput(&out).Report(report); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := `KIND         NAME              RESULT                       ERROR
+policy       broken            failed                       not acknowledged
+policy       working           created                      
+template     broken-template   skipped (dependency failed)  dependency policy [broken] failed
+template     working-template  created                      
+data stream  broken-stream     skipped (dependency failed)  dependency template [broken-template] failed
+
+Apply: 2 created, 0 updated, 0 unchanged, 1 failed, 2 skipped.
+`
+
+		if out.String() != expected {
+			t.Errorf("actual\n%s\nwant\n%s", out.String(), expected)
+		}
+	})
+}
+
+func TestJSONOutput(t *testing.T) {
+	g := graph.New([]string{"policy", "template"})
+	g.Add("policy", "invalid", "invalid")
+	g.Add("policy", "unreachable", "unreachable")
+	g.Add("policy", "working", "working")
+	template := g.Add("template", "template", "template")
+	g.AddDependency(template, "policy", "invalid")
+
+	resources, err := g.Sort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	output := NewJSONOutput(&out)
+
+	report := Run(&MockedApplier{}, resources, output)
+	if err := output.Report(report); err != nil {
+		t.Fatal(err)
+	}
+
+	decoder := json.NewDecoder(&out)
+
+	var events []map[string]any
+	for decoder.More() {
+		var event map[string]any
+		if err := decoder.Decode(&event); err != nil {
+			t.Fatal(err)
+		}
+
+		events = append(events, event)
+	}
+
+	if len(events) != 5 {
+		t.Fatalf("expected 4 resource events and a report, got %v", events)
+	}
+
+	for _, event := range events[:4] {
+		delete(event, "duration_ms")
+	}
+
+	expected := []map[string]any{
+		{
+			"event":  "resource",
+			"kind":   "policy",
+			"name":   "invalid",
+			"status": "failed",
+			"result": "failed",
+			"error": map[string]any{
+				"type":    "illegal_argument_exception",
+				"reason":  "unknown setting",
+				"status":  float64(400),
+				"message": "cannot fetch current ILM policy: ELK API call failed with status code 400: unknown setting",
+			},
+		},
+		{
+			"event":  "resource",
+			"kind":   "policy",
+			"name":   "unreachable",
+			"status": "failed",
+			"result": "failed",
+			"error": map[string]any{
+				"type":    "connection_error",
+				"reason":  "connection refused",
+				"message": "cannot connect to ELK: connection refused",
+			},
+		},
+		{
+			"event":  "resource",
+			"kind":   "policy",
+			"name":   "working",
+			"action": "create",
+			"status": "applied",
+			"result": "created",
+		},
+		{
+			"event":  "resource",
+			"kind":   "template",
+			"name":   "template",
+			"status": "skipped",
+			"result": "skipped",
+			"error": map[string]any{
+				"type":    "dependency_failed",
+				"reason":  "dependency policy [invalid] failed",
+				"message": "dependency policy [invalid] failed",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(events[:4], expected) {
+		t.Errorf("actual %v\nwant %v", events[:4], expected)
+	}
+
+	expectedSummary := map[string]any{
+		"created":   float64(1),
+		"updated":   float64(0),
+		"unchanged": float64(0),
+		"failed":    float64(2),
+		"skipped":   float64(1),
+	}
+
+	if events[4]["event"] != "report" || events[4]["success"] != false {
+		t.Errorf("unexpected report %v", events[4])
+	}
+
+	if !reflect.DeepEqual(events[4]["summary"], expectedSummary) {
+		t.Errorf("actual %v\nwant %v", events[4]["summary"], expectedSummary)
+	}
+
+	if resources, ok := events[4]["resources"].([]any); !ok || len(resources) != 4 {
+		t.Errorf("unexpected report resources %v", events[4]["resources"])
+	}
+}
+
+func TestJSONOutput_WriteError(t *testing.T) {
+	g := graph.New([]string{"policy"})
+	g.Add("policy", "working", "working")
+
+	resources, err := g.Sort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := NewJSONOutput(&FailingWriter{})
+
+	report := Run(&MockedApplier{}, resources, output)
+
+	if err := output.Report(report); err == nil {
+		t.Errorf("expected write error")
+	}
+}
@@ -0,0 +1,187 @@
+package apply
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mihai-valentin/polyroll/internal/elk"
+	"github.com/mihai-valentin/polyroll/internal/graph"
+	"io"
+	"log"
+	"text/tabwriter"
+)
+
+type Output interface {
+	Applying(node *graph.Node)
+	Applied(result ResourceResult)
+	Report(report *Report) error
+}
+
+type TextOutput struct {
+	w io.Writer
+}
+
+func NewTextOutput(w io.Writer) *TextOutput {
+	return &TextOutput{w: w}
+}
+
+func (o *TextOutput) Applying(node *graph.Node) {
+	log.Printf("Applying %s [%s]...\n", node.Kind, node.Name)
+}
+
+func (o *TextOutput) Applied(result ResourceResult) {
+	switch result.outcome() {
+	case "created":
+		log.Printf("Successfully created %s [%s]\n", result.Kind, result.Name)
+	case "updated":
+		log.Printf("Successfully updated %s [%s]\n", result.Kind, result.Name)
+	case "unchanged":
+		log.Printf("%s [%s] is unchanged\n", result.Kind, result.Name)
+	case "failed":
+		log.Printf("Cannot apply %s [%s]: %s\n", result.Kind, result.Name, result.Err)
+	case "skipped":
+		log.Printf("Skipping %s [%s]: %s\n", result.Kind, result.Name, result.Err)
+	}
+}
+
+func (o *TextOutput) Report(report *Report) error {
+	tw := tabwriter.NewWriter(o.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tNAME\tRESULT\tERROR")
+
+	for _, result := range report.Resources {
+		label := result.outcome()
+		if result.Status == Skipped {
+			label = "skipped (dependency failed)"
+		}
+
+		details := ""
+		if result.Err != nil {
+			details = result.Err.Error()
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", result.Kind, result.Name, label, details)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	summary := report.Summary()
+
+	_, err := fmt.Fprintf(o.w, "\nApply: %d created, %d updated, %d unchanged, %d failed, %d skipped.\n",
+		summary["created"],
+		summary["updated"],
+		summary["unchanged"],
+		summary["failed"],
+		summary["skipped"],
+	)
+
+	return err
+}
+
+type JSONOutput struct {
+	encoder *json.Encoder
+	err     error
+}
+
+type jsonError struct {
+	Type    string `json:"type"`
+	Reason  string `json:"reason"`
+	Status  int    `json:"status,omitempty"`
+	Message string `json:"message"`
+}
+
+type jsonResourceEvent struct {
+	Event      string     `json:"event"`
+	Kind       string     `json:"kind"`
+	Name       string     `json:"name"`
+	Action     string     `json:"action,omitempty"`
+	Status     Status     `json:"status"`
+	Result     string     `json:"result"`
+	DurationMs int64      `json:"duration_ms"`
+	Error      *jsonError `json:"error,omitempty"`
+}
+
+type jsonReportEvent struct {
+	Event     string              `json:"event"`
+	Success   bool                `json:"success"`
+	Summary   map[string]int      `json:"summary"`
+	Resources []jsonResourceEvent `json:"resources"`
+}
+
+func NewJSONOutput(w io.Writer) *JSONOutput {
+	return &JSONOutput{encoder: json.NewEncoder(w)}
+}
+
+func (o *JSONOutput) Applying(node *graph.Node) {}
+
+func (o *JSONOutput) Applied(result ResourceResult) {
+	if o.err != nil {
+		return
+	}
+
+	o.err = o.encoder.Encode(newJSONResourceEvent(result))
+}
+
+func (o *JSONOutput) Report(report *Report) error {
+	if o.err != nil {
+		return fmt.Errorf("cannot write resource event: %w", o.err)
+	}
+
+	resources := make([]jsonResourceEvent, 0, len(report.Resources))
+	for _, result := range report.Resources {
+		resources = append(resources, newJSONResourceEvent(result))
+	}
+
+	return o.encoder.Encode(jsonReportEvent{
+		Event:     "report",
+		Success:   !report.HasFailures(),
+		Summary:   report.Summary(),
+		Resources: resources,
+	})
+}
+
+func newJSONResourceEvent(result ResourceResult) jsonResourceEvent {
+	return jsonResourceEvent{
+		Event:      "resource",
+		Kind:       result.Kind,
+		Name:       result.Name,
+		Action:     string(result.Action),
+		Status:     result.Status,
+		Result:     result.outcome(),
+		DurationMs: result.Duration.Milliseconds(),
+		Error:      newJSONError(result),
+	}
+}
+
+func newJSONError(result ResourceResult) *jsonError {
+	if result.Err == nil {
+		return nil
+	}
+
+	details := &jsonError{
+		Type:    "error",
+		Reason:  result.Err.Error(),
+		Message: result.Err.Error(),
+	}
+
+	var responseError *elk.ResponseError
+	var connectionError *elk.ConnectionError
+
+	switch {
+	case result.Status == Skipped:
+		details.Type = "dependency_failed"
+	case errors.As(result.Err, &responseError):
+		if responseError.Type != "" {
+			details.Type = responseError.Type
+		}
+
+		details.Reason = responseError.Reason
+		details.Status = responseError.StatusCode
+	case errors.As(result.Err, &connectionError):
+		details.Type = "connection_error"
+		details.Reason = connectionError.Err.Error()
+	}
+
+	return details
+}
@@ -3,38 +3,492 @@ package internal
 import (
 	"errors"
 	"fmt"
+	"github.com/mihai-valentin/polyroll/internal/elk"
+	"github.com/mihai-valentin/polyroll/internal/graph"
 	"github.com/mihai-valentin/polyroll/internal/resource"
 	"gopkg.in/yaml.v3"
 	"os"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 )
 
 type Config struct {
-	ElkHost        string
-	AuthToken      string
-	IlmPolicies    []*resource.IlmPolicy     `yaml:"policies"`
-	IndexTemplates []*resource.IndexTemplate `yaml:"templates"`
+	ElkHosts             []string
+	HostSelection        string
+	DeadHostCooldown     time.Duration
+	Auth                 elk.Auth
+	TLS                  elk.TLS
+	Timeout              time.Duration
+	Retry                elk.Retry
+	SnapshotRepositories []*resource.SnapshotRepository `yaml:"repositories"`
+	SlmPolicies          []*resource.SlmPolicy          `yaml:"slm"`
+	IngestPipelines      []*resource.IngestPipeline     `yaml:"pipelines"`
+	IlmPolicies          []*resource.IlmPolicy          `yaml:"policies"`
+	ComponentTemplates   []*resource.ComponentTemplate  `yaml:"components"`
+	IndexTemplates       []*resource.IndexTemplate      `yaml:"templates"`
+	DataStreams          []*resource.DataStream         `yaml:"-"`
+	BootstrapIndices     []*resource.BootstrapIndex     `yaml:"-"`
+}
+
+var caFingerprintPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+var durationPattern = regexp.MustCompile(`^\d+(h|m|s|ms)$`)
+
+var resourceKindOrder = []string{
+	resource.SnapshotRepositoryKind,
+	resource.SlmPolicyKind,
+	resource.IngestPipelineKind,
+	resource.IlmPolicyKind,
+	resource.ComponentTemplateKind,
+	resource.IndexTemplateKind,
+	resource.DataStreamKind,
+	resource.BootstrapIndexKind,
+}
+
+type yamlConfigSchemaPolicyPhase struct {
+	MinAge                    string `yaml:"min_age"`
+	Priority                  *int   `yaml:"priority"`
+	SetPriority               *bool  `yaml:"setPriority"`
+	resource.IlmPolicyActions `yaml:",inline"`
+	undeclared                bool
 }
 
 type yamlConfigSchemaPolicyPhases struct {
-	Warm   uint `yaml:"warm"`
-	Cold   uint `yaml:"cold"`
-	Delete uint `yaml:"delete"`
+	Hot    *yamlConfigSchemaPolicyPhase `yaml:"hot"`
+	Warm   *yamlConfigSchemaPolicyPhase `yaml:"warm"`
+	Cold   *yamlConfigSchemaPolicyPhase `yaml:"cold"`
+	Frozen *yamlConfigSchemaPolicyPhase `yaml:"frozen"`
+	Delete *yamlConfigSchemaPolicyPhase `yaml:"delete"`
+}
+
+func (p *yamlConfigSchemaPolicyPhase) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		if err := node.Decode(&p.MinAge); err != nil {
+			return err
+		}
+
+		p.undeclared = p.MinAge != "" && strings.Trim(p.MinAge, "0") == ""
+
+		return nil
+	}
+
+	type plain yamlConfigSchemaPolicyPhase
+
+	return node.Decode((*plain)(p))
+}
+
+func (p *yamlConfigSchemaPolicyPhase) build() *resource.IlmPolicyPhase {
+	if p == nil || p.undeclared {
+		return nil
+	}
+
+	return &resource.IlmPolicyPhase{
+		MinAge:       resource.TimeValue(p.MinAge),
+		Priority:     p.Priority,
+		OmitPriority: p.SetPriority != nil && !*p.SetPriority,
+		Actions:      p.IlmPolicyActions,
+	}
 }
 
 type yamlConfigSchemaPolicy struct {
 	Phases yamlConfigSchemaPolicyPhases `yaml:"phases"`
 }
 
+func (p yamlConfigSchemaPolicy) build(name string) *resource.IlmPolicy {
+	return &resource.IlmPolicy{
+		Name:   name,
+		Hot:    p.Phases.Hot.build(),
+		Warm:   p.Phases.Warm.build(),
+		Cold:   p.Phases.Cold.build(),
+		Frozen: p.Phases.Frozen.build(),
+		Delete: p.Phases.Delete.build(),
+	}
+}
+
+type yamlConfigSchemaComponent struct {
+	Settings resource.Settings `yaml:"settings"`
+	Mappings map[string]any    `yaml:"mappings"`
+	Aliases  map[string]any    `yaml:"aliases"`
+}
+
+type yamlConfigSchemaRepository struct {
+	Type     string            `yaml:"type"`
+	Settings resource.Settings `yaml:"settings"`
+	Verify   *bool             `yaml:"verify"`
+}
+
+func (r yamlConfigSchemaRepository) build(name string) *resource.SnapshotRepository {
+	return &resource.SnapshotRepository{
+		Name:     name,
+		Type:     r.Type,
+		Settings: r.Settings,
+		Verify:   r.Verify,
+	}
+}
+
+type yamlConfigSchemaSlmPolicy struct {
+	Schedule     string                       `yaml:"schedule"`
+	SnapshotName string                       `yaml:"snapshotName"`
+	Repository   string                       `yaml:"repository"`
+	Indices      []string                     `yaml:"indices"`
+	Retention    *resource.SlmPolicyRetention `yaml:"retention"`
+}
+
+func (p yamlConfigSchemaSlmPolicy) build(name string) *resource.SlmPolicy {
+	return &resource.SlmPolicy{
+		Name:         name,
+		Schedule:     p.Schedule,
+		SnapshotName: p.SnapshotName,
+		Repository:   p.Repository,
+		Indices:      p.Indices,
+		Retention:    p.Retention,
+	}
+}
+
+type yamlConfigSchemaPipeline struct {
+	Description string           `yaml:"description"`
+	Processors  []map[string]any `yaml:"processors"`
+	OnFailure   []map[string]any `yaml:"on_failure"`
+	Version     *int             `yaml:"version"`
+}
+
+func (p yamlConfigSchemaPipeline) build(name string) *resource.IngestPipeline {
+	return &resource.IngestPipeline{
+		Name:        name,
+		Description: p.Description,
+		Processors:  p.Processors,
+		OnFailure:   p.OnFailure,
+		Version:     p.Version,
+	}
+}
+
 type yamlConfigSchemaTemplate struct {
-	Policy   string   `yaml:"policy"`
-	Patterns []string `yaml:"patterns"`
+	Policy          string                     `yaml:"policy"`
+	Patterns        []string                   `yaml:"patterns"`
+	ComposedOf      []string                   `yaml:"composed_of"`
+	Settings        resource.Settings          `yaml:"settings"`
+	Mappings        map[string]any             `yaml:"mappings"`
+	Aliases         map[string]any             `yaml:"aliases"`
+	Priority        *uint                      `yaml:"priority"`
+	Version         *int                       `yaml:"version"`
+	Meta            map[string]any             `yaml:"_meta"`
+	DataStream      yamlConfigSchemaDataStream `yaml:"dataStream"`
+	RolloverAlias   string                     `yaml:"rolloverAlias"`
+	DefaultPipeline string                     `yaml:"defaultPipeline"`
+	FinalPipeline   string                     `yaml:"finalPipeline"`
+}
+
+type yamlConfigSchemaDataStream struct {
+	enabled bool
+	options resource.IndexTemplateDataStream
+}
+
+func (d *yamlConfigSchemaDataStream) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&d.enabled)
+	}
+
+	d.enabled = true
+
+	return node.Decode(&d.options)
+}
+
+func (d yamlConfigSchemaDataStream) build() *resource.IndexTemplateDataStream {
+	if !d.enabled {
+		return nil
+	}
+
+	options := d.options
+
+	return &options
+}
+
+func (t yamlConfigSchemaTemplate) build(name string) *resource.IndexTemplate {
+	return &resource.IndexTemplate{
+		Name:            name,
+		IlmPolicyName:   t.Policy,
+		Patterns:        t.Patterns,
+		ComposedOf:      t.ComposedOf,
+		Settings:        t.Settings,
+		Mappings:        t.Mappings,
+		Aliases:         t.Aliases,
+		Priority:        t.Priority,
+		Version:         t.Version,
+		Meta:            t.Meta,
+		DataStream:      t.DataStream.build(),
+		RolloverAlias:   t.RolloverAlias,
+		DefaultPipeline: t.DefaultPipeline,
+		FinalPipeline:   t.FinalPipeline,
+	}
+}
+
+type yamlConfigSchemaAuth struct {
+	Type   string `yaml:"type"`
+	ApiKey string `yaml:"apiKey"`
+	Token  string `yaml:"token"`
+}
+
+type yamlConfigSchemaElasticsearch struct {
+	Host             string                   `yaml:"host"`
+	Hosts            []string                 `yaml:"hosts"`
+	HostSelection    string                   `yaml:"hostSelection"`
+	DeadHostCooldown yamlConfigSchemaDuration `yaml:"deadHostCooldown"`
+	BasicAuthToken   string                   `yaml:"basicAuthToken"`
+	Username         string                   `yaml:"username"`
+	Password         string                   `yaml:"password"`
+	Auth             yamlConfigSchemaAuth     `yaml:"auth"`
+	TLS              yamlConfigSchemaTLS      `yaml:"tls"`
+	Timeout          yamlConfigSchemaDuration `yaml:"timeout"`
+	Retry            yamlConfigSchemaRetry    `yaml:"retry"`
+}
+
+type yamlConfigSchemaDuration string
+
+func (d yamlConfigSchemaDuration) Duration() (time.Duration, error) {
+	if d == "" {
+		return 0, nil
+	}
+
+	if !durationPattern.MatchString(string(d)) {
+		return 0, errors.New(fmt.Sprintf("invalid duration [%s], expected an integer followed by one of h, m, s, ms", d))
+	}
+
+	return resource.TimeValue(d).Duration()
+}
+
+type yamlConfigSchemaRetry struct {
+	MaxRetries     *int                     `yaml:"maxRetries"`
+	InitialBackoff yamlConfigSchemaDuration `yaml:"initialBackoff"`
+	MaxBackoff     yamlConfigSchemaDuration `yaml:"maxBackoff"`
+}
+
+func (r yamlConfigSchemaRetry) build() elk.Retry {
+	retry := elk.DefaultRetry
+
+	if r.MaxRetries != nil {
+		retry.MaxRetries = *r.MaxRetries
+	}
+
+	if initialBackoff, _ := r.InitialBackoff.Duration(); initialBackoff > 0 {
+		retry.InitialBackoff = initialBackoff
+	}
+
+	if maxBackoff, _ := r.MaxBackoff.Duration(); maxBackoff > 0 {
+		retry.MaxBackoff = maxBackoff
+	}
+
+	return retry
+}
+
+func (r yamlConfigSchemaRetry) validate() error {
+	if r.MaxRetries != nil && *r.MaxRetries < 0 {
+		return errors.New(fmt.Sprintf("retry.maxRetries must not be negative, got %d", *r.MaxRetries))
+	}
+
+	if _, err := r.InitialBackoff.Duration(); err != nil {
+		return fmt.Errorf("invalid retry.initialBackoff: %w", err)
+	}
+
+	if _, err := r.MaxBackoff.Duration(); err != nil {
+		return fmt.Errorf("invalid retry.maxBackoff: %w", err)
+	}
+
+	retry := r.build()
+	if retry.InitialBackoff > retry.MaxBackoff {
+		return errors.New(fmt.Sprintf("retry.initialBackoff [%s] must not be greater than retry.maxBackoff [%s]",
+			retry.InitialBackoff,
+			retry.MaxBackoff,
+		))
+	}
+
+	return nil
+}
+
+func (e yamlConfigSchemaElasticsearch) timeout() time.Duration {
+	if timeout, _ := e.Timeout.Duration(); timeout > 0 {
+		return timeout
+	}
+
+	return elk.DefaultTimeout
+}
+
+type yamlConfigSchemaTLS struct {
+	CAFile             string `yaml:"caFile"`
+	CertFile           string `yaml:"certFile"`
+	KeyFile            string `yaml:"keyFile"`
+	CAFingerprint      string `yaml:"caFingerprint"`
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify"`
+}
+
+func (t yamlConfigSchemaTLS) build() elk.TLS {
+	return elk.TLS{
+		CAFile:             t.CAFile,
+		CertFile:           t.CertFile,
+		KeyFile:            t.KeyFile,
+		CAFingerprint:      t.CAFingerprint,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+}
+
+func (t yamlConfigSchemaTLS) validate() error {
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return errors.New("tls.certFile and tls.keyFile must be set together")
+	}
+
+	if t.CAFingerprint != "" && (t.CAFile != "" || t.InsecureSkipVerify) {
+		return errors.New("tls.caFingerprint cannot be combined with tls.caFile or tls.insecureSkipVerify")
+	}
+
+	if t.CAFile != "" && t.InsecureSkipVerify {
+		return errors.New("tls.caFile cannot be combined with tls.insecureSkipVerify")
+	}
+
+	if t.CAFingerprint != "" && !caFingerprintPattern.MatchString(elk.NormalizeFingerprint(t.CAFingerprint)) {
+		return errors.New(fmt.Sprintf("tls.caFingerprint [%s] is not a hex encoded SHA-256 fingerprint",
+			t.CAFingerprint,
+		))
+	}
+
+	return nil
+}
+
+func (e yamlConfigSchemaElasticsearch) build() elk.Auth {
+	switch e.Auth.Type {
+	case "", elk.AuthBasic:
+		return elk.Auth{
+			Type:     elk.AuthBasic,
+			Token:    e.BasicAuthToken,
+			Username: e.Username,
+			Password: e.Password,
+		}
+	case elk.AuthApiKey:
+		return elk.Auth{Type: elk.AuthApiKey, Token: e.Auth.ApiKey}
+	case elk.AuthBearer:
+		return elk.Auth{Type: elk.AuthBearer, Token: e.Auth.Token}
+	}
+
+	return elk.Auth{Type: e.Auth.Type}
+}
+
+func (e yamlConfigSchemaElasticsearch) hosts() []string {
+	hosts := e.Hosts
+	if e.Host != "" {
+		hosts = []string{e.Host}
+	}
+
+	normalized := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		normalized = append(normalized, normalizeElkHostValue(host))
+	}
+
+	return normalized
+}
+
+func (e yamlConfigSchemaElasticsearch) hostSelection() string {
+	if e.HostSelection == "" {
+		return elk.HostSelectionRoundRobin
+	}
+
+	return e.HostSelection
+}
+
+func (e yamlConfigSchemaElasticsearch) deadHostCooldown() time.Duration {
+	if cooldown, _ := e.DeadHostCooldown.Duration(); cooldown > 0 {
+		return cooldown
+	}
+
+	return elk.DefaultDeadHostCooldown
+}
+
+func (e yamlConfigSchemaElasticsearch) validate() error {
+	if e.Host != "" && len(e.Hosts) > 0 {
+		return errors.New("both ELK host and hosts are set, use only one of them")
+	}
+
+	if e.Host == "" && len(e.Hosts) == 0 {
+		return errors.New("empty ELK host value")
+	}
+
+	for _, host := range e.Hosts {
+		if host == "" {
+			return errors.New("empty ELK host value in hosts")
+		}
+	}
+
+	switch e.hostSelection() {
+	case elk.HostSelectionRoundRobin, elk.HostSelectionSticky:
+	default:
+		return errors.New(fmt.Sprintf("unknown ELK host selection [%s], expected one of roundRobin, sticky",
+			e.HostSelection,
+		))
+	}
+
+	if _, err := e.DeadHostCooldown.Duration(); err != nil {
+		return fmt.Errorf("invalid deadHostCooldown: %w", err)
+	}
+
+	if err := e.TLS.validate(); err != nil {
+		return err
+	}
+
+	if _, err := e.Timeout.Duration(); err != nil {
+		return fmt.Errorf("invalid timeout: %w", err)
+	}
+
+	if err := e.Retry.validate(); err != nil {
+		return err
+	}
+
+	auth := e.build()
+	hasCredentials := e.Username != "" || e.Password != ""
+
+	if hasCredentials && auth.Type != elk.AuthBasic {
+		return errors.New(fmt.Sprintf("ELK username and password are only allowed with the basic auth type, got [%s]",
+			auth.Type,
+		))
+	}
+
+	switch auth.Type {
+	case elk.AuthBasic:
+		if hasCredentials && e.BasicAuthToken != "" {
+			return errors.New("both ELK basicAuthToken and username/password are set, use only one of them")
+		}
+
+		if hasCredentials && (e.Username == "" || e.Password == "") {
+			return errors.New("ELK username and password must be set together")
+		}
+
+		if !hasCredentials && auth.Token == "" {
+			return errors.New("empty ELK auth token value, set basicAuthToken or username and password")
+		}
+	case elk.AuthApiKey:
+		if auth.Token == "" {
+			return errors.New("empty ELK API key value, set auth.apiKey")
+		}
+	case elk.AuthBearer:
+		if auth.Token == "" {
+			return errors.New("empty ELK bearer token value, set auth.token")
+		}
+	case elk.AuthNone:
+	default:
+		return errors.New(fmt.Sprintf("unknown ELK auth type [%s], expected one of basic, apiKey, bearer, none",
+			auth.Type,
+		))
+	}
+
+	return nil
 }
 
 type yamlConfigSchema struct {
-	Elasticsearch map[string]string                   `yaml:"elasticsearch"`
-	Polices       map[string]yamlConfigSchemaPolicy   `yaml:"policies"`
-	Templates     map[string]yamlConfigSchemaTemplate `yaml:"templates"`
+	Elasticsearch yamlConfigSchemaElasticsearch         `yaml:"elasticsearch"`
+	Repositories  map[string]yamlConfigSchemaRepository `yaml:"repositories"`
+	SlmPolicies   map[string]yamlConfigSchemaSlmPolicy  `yaml:"slm"`
+	Pipelines     map[string]yamlConfigSchemaPipeline   `yaml:"pipelines"`
+	Polices       map[string]yamlConfigSchemaPolicy     `yaml:"policies"`
+	Components    map[string]yamlConfigSchemaComponent  `yaml:"components"`
+	Templates     map[string]yamlConfigSchemaTemplate   `yaml:"templates"`
 }
 
 func ReadConfigFromFile(pathToFile string) (*Config, error) {
@@ -52,16 +506,64 @@ func ReadConfigFromFile(pathToFile string) (*Config, error) {
 		return nil, fmt.Errorf("invalid config schema [%s]: %w", pathToFile, err)
 	}
 
-	return buildFromSchema(ycs), nil
+	config := buildFromSchema(ycs)
+
+	if _, err := config.Resources(); err != nil {
+		return nil, fmt.Errorf("invalid config schema [%s]: %w", pathToFile, err)
+	}
+
+	return config, nil
 }
 
 func isConfigSchemaValid(schema yamlConfigSchema) (bool, error) {
-	if schema.Elasticsearch["host"] == "" {
-		return false, errors.New("empty ELK host value")
+	if err := schema.Elasticsearch.validate(); err != nil {
+		return false, err
 	}
 
-	if schema.Elasticsearch["basicAuthToken"] == "" {
-		return false, errors.New("empty ELK auth token value")
+	for repositoryName, repositoryConfig := range schema.Repositories {
+		if err := repositoryConfig.build(repositoryName).Validate(); err != nil {
+			return false, errors.New(fmt.Sprintf("snapshot repository [%s] is invalid: %s",
+				repositoryName,
+				err,
+			))
+		}
+	}
+
+	for slmPolicyName, slmPolicyConfig := range schema.SlmPolicies {
+		if err := slmPolicyConfig.build(slmPolicyName).Validate(); err != nil {
+			return false, errors.New(fmt.Sprintf("SLM policy [%s] is invalid: %s",
+				slmPolicyName,
+				err,
+			))
+		}
+	}
+
+	for pipelineName, pipelineConfig := range schema.Pipelines {
+		if len(pipelineConfig.Processors) == 0 {
+			return false, errors.New(fmt.Sprintf("ingest pipeline [%s] has empty processors list",
+				pipelineName,
+			))
+		}
+	}
+
+	for policyName, policyConfig := range schema.Polices {
+		policy := policyConfig.build(policyName)
+
+		if err := policy.Validate(); err != nil {
+			return false, errors.New(fmt.Sprintf("policy [%s] is invalid: %s",
+				policyName,
+				err,
+			))
+		}
+
+		for _, slmPolicyName := range policy.SlmPolicyNames() {
+			if _, ok := schema.SlmPolicies[slmPolicyName]; !ok {
+				return false, errors.New(fmt.Sprintf("policy [%s] waits for undefined SLM policy [%s]",
+					policyName,
+					slmPolicyName,
+				))
+			}
+		}
 	}
 
 	for templateName, templateConfig := range schema.Templates {
@@ -77,39 +579,307 @@ func isConfigSchemaValid(schema yamlConfigSchema) (bool, error) {
 				templateConfig.Policy,
 			))
 		}
+
+		if templateConfig.Settings.Has("index.lifecycle.name") {
+			return false, errors.New(fmt.Sprintf("index template [%s] sets index.lifecycle.name in settings, use policy instead",
+				templateName,
+			))
+		}
+
+		if templateConfig.Settings.Has("index.lifecycle.rollover_alias") {
+			return false, errors.New(fmt.Sprintf("index template [%s] sets index.lifecycle.rollover_alias in settings, use rolloverAlias instead",
+				templateName,
+			))
+		}
+
+		if err := validateTemplatePipelines(templateName, templateConfig, schema.Pipelines); err != nil {
+			return false, err
+		}
+
+		if err := validateTemplateDataStream(templateConfig.build(templateName), schema.Polices); err != nil {
+			return false, err
+		}
+
+		if err := validateTemplateRolloverAlias(templateConfig.build(templateName), schema.Polices); err != nil {
+			return false, err
+		}
+
+		for _, componentName := range templateConfig.ComposedOf {
+			if _, ok := schema.Components[componentName]; !ok {
+				return false, errors.New(fmt.Sprintf("index template [%s] is composed of undefined component [%s]",
+					templateName,
+					componentName,
+				))
+			}
+		}
+	}
+
+	if err := validateTemplatesPriorities(schema.Templates); err != nil {
+		return false, err
 	}
 
 	return true, nil
 }
 
+func validateTemplatePipelines(templateName string, templateConfig yamlConfigSchemaTemplate, pipelines map[string]yamlConfigSchemaPipeline) error {
+	for setting, pipelineName := range map[string]string{
+		"index.default_pipeline": templateConfig.DefaultPipeline,
+		"index.final_pipeline":   templateConfig.FinalPipeline,
+	} {
+		if pipelineName == "" {
+			continue
+		}
+
+		if templateConfig.Settings.Has(setting) {
+			return errors.New(fmt.Sprintf("index template [%s] sets both %s in settings and a pipeline option",
+				templateName,
+				setting,
+			))
+		}
+
+		if _, ok := pipelines[pipelineName]; !ok {
+			return errors.New(fmt.Sprintf("index template [%s] requires undefined ingest pipeline [%s]",
+				templateName,
+				pipelineName,
+			))
+		}
+	}
+
+	return nil
+}
+
+func validateTemplateDataStream(template *resource.IndexTemplate, policies map[string]yamlConfigSchemaPolicy) error {
+	if template.DataStream == nil {
+		return nil
+	}
+
+	policy := policies[template.IlmPolicyName].build(template.IlmPolicyName)
+	if policy.Hot == nil || policy.Hot.Actions.Rollover == nil {
+		return errors.New(fmt.Sprintf("data stream index template [%s] requires policy [%s] with hot phase rollover",
+			template.Name,
+			template.IlmPolicyName,
+		))
+	}
+
+	for _, dataStreamName := range template.DataStream.Create {
+		if !template.Matches(dataStreamName) {
+			return errors.New(fmt.Sprintf("data stream [%s] does not match index template [%s] patterns",
+				dataStreamName,
+				template.Name,
+			))
+		}
+	}
+
+	return nil
+}
+
+func validateTemplateRolloverAlias(template *resource.IndexTemplate, policies map[string]yamlConfigSchemaPolicy) error {
+	bootstrapIndex := template.BootstrapIndex()
+	if bootstrapIndex == nil {
+		return nil
+	}
+
+	if template.DataStream != nil {
+		return errors.New(fmt.Sprintf("data stream index template [%s] cannot define a rollover alias",
+			template.Name,
+		))
+	}
+
+	policy := policies[template.IlmPolicyName].build(template.IlmPolicyName)
+	if policy.Hot == nil || policy.Hot.Actions.Rollover == nil {
+		return errors.New(fmt.Sprintf("index template [%s] with rollover alias requires policy [%s] with hot phase rollover",
+			template.Name,
+			template.IlmPolicyName,
+		))
+	}
+
+	if !template.Matches(bootstrapIndex.Name) {
+		return errors.New(fmt.Sprintf("bootstrap index [%s] does not match index template [%s] patterns",
+			bootstrapIndex.Name,
+			template.Name,
+		))
+	}
+
+	if template.Matches(bootstrapIndex.Alias) {
+		return errors.New(fmt.Sprintf("rollover alias [%s] must not match index template [%s] patterns",
+			bootstrapIndex.Alias,
+			template.Name,
+		))
+	}
+
+	return nil
+}
+
+func validateTemplatesPriorities(templates map[string]yamlConfigSchemaTemplate) error {
+	names := sortedNames(templates)
+
+	for i, name := range names {
+		template := templates[name].build(name)
+
+		for _, otherName := range names[i+1:] {
+			other := templates[otherName].build(otherName)
+
+			if template.EffectivePriority() == other.EffectivePriority() && template.Overlaps(other) {
+				return errors.New(fmt.Sprintf("index templates [%s] and [%s] have overlapping patterns and the same priority %d",
+					name,
+					otherName,
+					template.EffectivePriority(),
+				))
+			}
+		}
+	}
+
+	return nil
+}
+
 func buildFromSchema(ycs yamlConfigSchema) *Config {
 	c := &Config{
-		ElkHost:        normalizeElkHostValue(ycs.Elasticsearch["host"]),
-		AuthToken:      ycs.Elasticsearch["basicAuthToken"],
-		IlmPolicies:    []*resource.IlmPolicy{},
-		IndexTemplates: []*resource.IndexTemplate{},
-	}
-
-	for name, config := range ycs.Polices {
-		c.IlmPolicies = append(c.IlmPolicies, &resource.IlmPolicy{
-			Name:   name,
-			Warm:   config.Phases.Warm,
-			Cold:   config.Phases.Cold,
-			Delete: config.Phases.Delete,
-		})
+		ElkHosts:             ycs.Elasticsearch.hosts(),
+		HostSelection:        ycs.Elasticsearch.hostSelection(),
+		DeadHostCooldown:     ycs.Elasticsearch.deadHostCooldown(),
+		Auth:                 ycs.Elasticsearch.build(),
+		TLS:                  ycs.Elasticsearch.TLS.build(),
+		Timeout:              ycs.Elasticsearch.timeout(),
+		Retry:                ycs.Elasticsearch.Retry.build(),
+		SnapshotRepositories: []*resource.SnapshotRepository{},
+		SlmPolicies:          []*resource.SlmPolicy{},
+		IngestPipelines:      []*resource.IngestPipeline{},
+		IlmPolicies:          []*resource.IlmPolicy{},
+		ComponentTemplates:   []*resource.ComponentTemplate{},
+		IndexTemplates:       []*resource.IndexTemplate{},
+		DataStreams:          []*resource.DataStream{},
+		BootstrapIndices:     []*resource.BootstrapIndex{},
+	}
+
+	for _, name := range sortedNames(ycs.Repositories) {
+		c.SnapshotRepositories = append(c.SnapshotRepositories, ycs.Repositories[name].build(name))
+	}
+
+	for _, name := range sortedNames(ycs.SlmPolicies) {
+		c.SlmPolicies = append(c.SlmPolicies, ycs.SlmPolicies[name].build(name))
+	}
+
+	for _, name := range sortedNames(ycs.Pipelines) {
+		c.IngestPipelines = append(c.IngestPipelines, ycs.Pipelines[name].build(name))
+	}
+
+	for _, name := range sortedNames(ycs.Polices) {
+		c.IlmPolicies = append(c.IlmPolicies, ycs.Polices[name].build(name))
 	}
 
-	for name, config := range ycs.Templates {
-		c.IndexTemplates = append(c.IndexTemplates, &resource.IndexTemplate{
-			Name:          name,
-			IlmPolicyName: config.Policy,
-			Patterns:      config.Patterns,
+	for _, name := range sortedNames(ycs.Components) {
+		config := ycs.Components[name]
+
+		c.ComponentTemplates = append(c.ComponentTemplates, &resource.ComponentTemplate{
+			Name:     name,
+			Settings: config.Settings,
+			Mappings: config.Mappings,
+			Aliases:  config.Aliases,
 		})
 	}
 
+	for _, name := range sortedNames(ycs.Templates) {
+		indexTemplate := ycs.Templates[name].build(name)
+		c.IndexTemplates = append(c.IndexTemplates, indexTemplate)
+
+		if bootstrapIndex := indexTemplate.BootstrapIndex(); bootstrapIndex != nil {
+			c.BootstrapIndices = append(c.BootstrapIndices, bootstrapIndex)
+		}
+
+		if indexTemplate.DataStream == nil {
+			continue
+		}
+
+		for _, dataStreamName := range indexTemplate.DataStream.Create {
+			c.DataStreams = append(c.DataStreams, &resource.DataStream{
+				Name:          dataStreamName,
+				IndexTemplate: name,
+			})
+		}
+	}
+
 	return c
 }
 
+func (c *Config) Resources() ([]*graph.Node, error) {
+	return c.resourceGraph().Sort()
+}
+
+func (c *Config) resourceGraph() *graph.Graph {
+	g := graph.New(resourceKindOrder)
+
+	for _, repository := range c.SnapshotRepositories {
+		g.Add(resource.SnapshotRepositoryKind, repository.Name, repository)
+	}
+
+	for _, slmPolicy := range c.SlmPolicies {
+		node := g.Add(resource.SlmPolicyKind, slmPolicy.Name, slmPolicy)
+		g.AddDependency(node, resource.SnapshotRepositoryKind, slmPolicy.Repository)
+	}
+
+	for _, pipeline := range c.IngestPipelines {
+		g.Add(resource.IngestPipelineKind, pipeline.Name, pipeline)
+	}
+
+	for _, pipeline := range c.IngestPipelines {
+		node := g.Get(resource.IngestPipelineKind, pipeline.Name)
+
+		for _, pipelineName := range pipeline.PipelineNames() {
+			g.AddDependency(node, resource.IngestPipelineKind, pipelineName)
+		}
+	}
+
+	for _, policy := range c.IlmPolicies {
+		node := g.Add(resource.IlmPolicyKind, policy.Name, policy)
+
+		for _, repositoryName := range policy.SnapshotRepositoryNames() {
+			g.AddDependency(node, resource.SnapshotRepositoryKind, repositoryName)
+		}
+
+		for _, slmPolicyName := range policy.SlmPolicyNames() {
+			g.AddDependency(node, resource.SlmPolicyKind, slmPolicyName)
+		}
+	}
+
+	for _, componentTemplate := range c.ComponentTemplates {
+		g.Add(resource.ComponentTemplateKind, componentTemplate.Name, componentTemplate)
+	}
+
+	for _, indexTemplate := range c.IndexTemplates {
+		node := g.Add(resource.IndexTemplateKind, indexTemplate.Name, indexTemplate)
+		g.AddDependency(node, resource.IlmPolicyKind, indexTemplate.IlmPolicyName)
+		g.AddDependency(node, resource.IngestPipelineKind, indexTemplate.DefaultPipeline)
+		g.AddDependency(node, resource.IngestPipelineKind, indexTemplate.FinalPipeline)
+
+		for _, componentName := range indexTemplate.ComposedOf {
+			g.AddDependency(node, resource.ComponentTemplateKind, componentName)
+		}
+	}
+
+	for _, dataStream := range c.DataStreams {
+		node := g.Add(resource.DataStreamKind, dataStream.Name, dataStream)
+		g.AddDependency(node, resource.IndexTemplateKind, dataStream.IndexTemplate)
+	}
+
+	for _, bootstrapIndex := range c.BootstrapIndices {
+		node := g.Add(resource.BootstrapIndexKind, bootstrapIndex.Name, bootstrapIndex)
+		g.AddDependency(node, resource.IndexTemplateKind, bootstrapIndex.IndexTemplate)
+	}
+
+	return g
+}
+
+func sortedNames[T any](items map[string]T) []string {
+	names := make([]string, 0, len(items))
+	for name := range items {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
 func normalizeElkHostValue(elkHost string) string {
 	if strings.HasSuffix(elkHost, "/") {
 		return elkHost
@@ -1,10 +1,14 @@
 package internal
 
 import (
+	"fmt"
+	"github.com/mihai-valentin/polyroll/internal/elk"
 	"github.com/mihai-valentin/polyroll/internal/resource"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestReadConfigFromFile(t *testing.T) {
@@ -16,10 +20,20 @@ func TestReadConfigFromFile(t *testing.T) {
         `
 
 		expected := &Config{
-			ElkHost:        "hots/",
-			AuthToken:      "token",
-			IlmPolicies:    []*resource.IlmPolicy{},
-			IndexTemplates: []*resource.IndexTemplate{},
+			ElkHosts:             []string{"hots/"},
+			HostSelection:        elk.HostSelectionRoundRobin,
+			DeadHostCooldown:     elk.DefaultDeadHostCooldown,
+			Auth:                 elk.Auth{Type: elk.AuthBasic, Token: "token"},
+			Timeout:              elk.DefaultTimeout,
+			Retry:                elk.DefaultRetry,
+			SnapshotRepositories: []*resource.SnapshotRepository{},
+			SlmPolicies:          []*resource.SlmPolicy{},
+			IngestPipelines:      []*resource.IngestPipeline{},
+			IlmPolicies:          []*resource.IlmPolicy{},
+			ComponentTemplates:   []*resource.ComponentTemplate{},
+			IndexTemplates:       []*resource.IndexTemplate{},
+			DataStreams:          []*resource.DataStream{},
+			BootstrapIndices:     []*resource.BootstrapIndex{},
 		}
 
 		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
@@ -50,10 +64,20 @@ func TestReadConfigFromFile(t *testing.T) {
         `
 
 		expected := &Config{
-			ElkHost:        "hots/",
-			AuthToken:      "token",
-			IlmPolicies:    []*resource.IlmPolicy{},
-			IndexTemplates: []*resource.IndexTemplate{},
+			ElkHosts:             []string{"hots/"},
+			HostSelection:        elk.HostSelectionRoundRobin,
+			DeadHostCooldown:     elk.DefaultDeadHostCooldown,
+			Auth:                 elk.Auth{Type: elk.AuthBasic, Token: "token"},
+			Timeout:              elk.DefaultTimeout,
+			Retry:                elk.DefaultRetry,
+			SnapshotRepositories: []*resource.SnapshotRepository{},
+			SlmPolicies:          []*resource.SlmPolicy{},
+			IngestPipelines:      []*resource.IngestPipeline{},
+			IlmPolicies:          []*resource.IlmPolicy{},
+			ComponentTemplates:   []*resource.ComponentTemplate{},
+			IndexTemplates:       []*resource.IndexTemplate{},
+			DataStreams:          []*resource.DataStream{},
+			BootstrapIndices:     []*resource.BootstrapIndex{},
 		}
 
 		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
@@ -97,9 +121,9 @@ func TestReadConfigFromFile(t *testing.T) {
 
 		expectedIlmPolicy := resource.IlmPolicy{
 			Name:   "foo",
-			Warm:   1,
-			Cold:   30,
-			Delete: 60,
+			Warm:   &resource.IlmPolicyPhase{MinAge: "1"},
+			Cold:   &resource.IlmPolicyPhase{MinAge: "30"},
+			Delete: &resource.IlmPolicyPhase{MinAge: "60"},
 		}
 
 		expectedIndexTemplate := resource.IndexTemplate{
@@ -123,12 +147,12 @@ func TestReadConfigFromFile(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		if actual.ElkHost != "host/" {
-			t.Errorf("actual %v\nwant %v", actual.ElkHost, "host")
+		if !reflect.DeepEqual(actual.ElkHosts, []string{"host/"}) {
+			t.Errorf("actual %v\nwant %v", actual.ElkHosts, []string{"host/"})
 		}
 
-		if actual.AuthToken != "token" {
-			t.Errorf("actual %v\nwant %v", actual.AuthToken, "token")
+		if actual.Auth.Token != "token" {
+			t.Errorf("actual %v\nwant %v", actual.Auth.Token, "token")
 		}
 
 		if len(actual.IlmPolicies) != 1 {
@@ -260,4 +284,1934 @@ func TestReadConfigFromFile(t *testing.T) {
 			t.Fatal("undefined policy value should fail")
 		}
 	})
+	t.Run("Config with hot phase rollover", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  hot:
+                    rollover:
+                      max_age: "30d"
+                      max_primary_shard_size: "50gb"
+                      min_docs: 1
+                  delete: 60
+        `
+
+		expectedRollover := &resource.Rollover{
+			MaxAge:              "30d",
+			MaxPrimaryShardSize: "50gb",
+			MinDocs:             1,
+		}
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(actual.IlmPolicies[0].Hot.Actions.Rollover, expectedRollover) {
+			t.Errorf("actual %v\nwant %v", actual.IlmPolicies[0].Hot.Actions.Rollover, expectedRollover)
+		}
+	})
+
+	t.Run("Config with hot phase rollover without max conditions", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  hot:
+                    rollover:
+                      min_docs: 1
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+			t.Fatal("rollover without max conditions should fail")
+		}
+	})
+	t.Run("Config with phase actions", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  warm:
+                    min_age: 7
+                    shrink:
+                      number_of_shards: 1
+                    forcemerge:
+                      max_num_segments: 1
+                    readonly: true
+                  cold:
+                    min_age: 30
+                    allocate:
+                      require:
+                        box_type: "cold"
+        `
+
+		expectedWarm := &resource.IlmPolicyPhase{
+			MinAge: "7",
+			Actions: resource.IlmPolicyActions{
+				Shrink:     &resource.Shrink{NumberOfShards: 1},
+				Forcemerge: &resource.Forcemerge{MaxNumSegments: 1},
+				Readonly:   true,
+			},
+		}
+
+		expectedCold := &resource.IlmPolicyPhase{
+			MinAge: "30",
+			Actions: resource.IlmPolicyActions{
+				Allocate: &resource.Allocate{Require: map[string]string{"box_type": "cold"}},
+			},
+		}
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(actual.IlmPolicies[0].Warm, expectedWarm) {
+			t.Errorf("actual %v\nwant %v", actual.IlmPolicies[0].Warm, expectedWarm)
+		}
+
+		if !reflect.DeepEqual(actual.IlmPolicies[0].Cold, expectedCold) {
+			t.Errorf("actual %v\nwant %v", actual.IlmPolicies[0].Cold, expectedCold)
+		}
+	})
+
+	t.Run("Config with action not allowed in phase", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  cold:
+                    min_age: 30
+                    shrink:
+                      number_of_shards: 1
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+			t.Fatal("shrink action in cold phase should fail")
+		}
+	})
+	t.Run("Config with frozen phase", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  warm: 1
+                  cold: 7
+                  frozen:
+                    min_age: 30
+                    searchable_snapshot:
+                      snapshot_repository: "backups"
+                  delete:
+                    min_age: 90
+                    wait_for_snapshot:
+                      policy: "nightly-snapshots"
+                    delete_searchable_snapshot: false
+            
+            slm:
+              nightly-snapshots:
+                schedule: "0 30 1 * * ?"
+                snapshotName: "<nightly-snap-{now/d}>"
+                repository: "backups"
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		policy := actual.IlmPolicies[0]
+
+		if policy.Frozen == nil || policy.Frozen.Actions.SearchableSnapshot.SnapshotRepository != "backups" {
+			t.Errorf("expected frozen phase with searchable snapshot, got %v", policy.Frozen)
+		}
+
+		if policy.Delete == nil || policy.Delete.Actions.WaitForSnapshot.Policy != "nightly-snapshots" {
+			t.Errorf("expected delete phase with wait for snapshot, got %v", policy.Delete)
+		}
+
+		if policy.Delete.Actions.DeleteSearchableSnapshot == nil || *policy.Delete.Actions.DeleteSearchableSnapshot {
+			t.Errorf("expected delete_searchable_snapshot to be false")
+		}
+	})
+
+	t.Run("Config with frozen phase without searchable snapshot", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  frozen: 30
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+			t.Fatal("frozen phase without searchable snapshot should fail")
+		}
+	})
+	t.Run("Config with delete phase only", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  delete: 30
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, ok := actual.IlmPolicies[0].Schema()["policy"]["phases"]["delete"]; !ok {
+			t.Errorf("expected delete phase in policy schema")
+		}
+	})
+
+	t.Run("Config with zero phases", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  warm: 0
+                  cold: 0
+                  delete: 0
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		phases := actual.IlmPolicies[0].Schema()["policy"]["phases"]
+		for _, name := range []string{"warm", "cold", "delete"} {
+			if _, ok := phases[name]; ok {
+				t.Errorf("%s: 0 should not declare the phase, got %v", name, phases[name])
+			}
+		}
+	})
+
+	t.Run("Config with phases min_age out of order", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  warm: 30
+                  delete: 7
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+			t.Fatal("phases min_age out of order should fail")
+		}
+	})
+	t.Run("Config with min_age time units", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  warm: "12h"
+                  cold:
+                    min_age: 2
+                  delete:
+                    min_age: "72h"
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		phases := actual.IlmPolicies[0].Schema()["policy"]["phases"]
+
+		for name, expected := range map[string]string{"warm": "12h", "cold": "2d", "delete": "72h"} {
+			if phases[name].MinAge != expected {
+				t.Errorf("[%s] phase: actual %v\nwant %v", name, phases[name].MinAge, expected)
+			}
+		}
+	})
+
+	t.Run("Config with invalid min_age time unit", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  warm: "2w"
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+			t.Fatal("invalid min_age time unit should fail")
+		}
+	})
+	t.Run("Config with phase priorities", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  hot:
+                    priority: 200
+                  warm:
+                    min_age: 1
+                    priority: 75
+                  cold: 30
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		phases := actual.IlmPolicies[0].Schema()["policy"]["phases"]
+
+		for name, expected := range map[string]int{"hot": 200, "warm": 75, "cold": 0} {
+			if phases[name].Actions["set_priority"]["priority"] != expected {
+				t.Errorf("[%s] phase: actual %v\nwant %v", name, phases[name].Actions["set_priority"]["priority"], expected)
+			}
+		}
+	})
+
+	t.Run("Config with negative phase priority", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  warm:
+                    min_age: 1
+                    priority: -5
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+			t.Fatal("negative phase priority should fail")
+		}
+	})
+
+	t.Run("Config with phases without set_priority", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  hot:
+                    setPriority: false
+                  warm:
+                    min_age: 1
+                    setPriority: false
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		phases := actual.IlmPolicies[0].Schema()["policy"]["phases"]
+
+		for _, name := range []string{"hot", "warm"} {
+			if _, ok := phases[name].Actions["set_priority"]; ok {
+				t.Errorf("[%s] phase should not contain set_priority, got %v", name, phases[name].Actions)
+			}
+		}
+	})
+
+	t.Run("Config with priority and setPriority false", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  warm:
+                    min_age: 1
+                    priority: 10
+                    setPriority: false
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+			t.Fatal("priority combined with setPriority false should fail")
+		}
+	})
+	t.Run("Config with component templates", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  delete: 30
+            
+            components:
+              base-settings:
+                settings:
+                  number_of_shards: 1
+                mappings:
+                  properties:
+                    "@timestamp":
+                      type: "date"
+            
+            templates:
+              template-foo:
+                policy: "foo"
+                patterns: [ "index-foo-*" ]
+                composed_of: [ "base-settings" ]
+        `
+
+		expectedComponentTemplate := resource.ComponentTemplate{
+			Name:     "base-settings",
+			Settings: resource.Settings{"number_of_shards": 1},
+			Mappings: map[string]any{
+				"properties": map[string]any{
+					"@timestamp": map[string]any{"type": "date"},
+				},
+			},
+		}
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(actual.ComponentTemplates) != 1 {
+			t.Fatalf("expected exact 1 component template to be parsed, parsed %v", len(actual.ComponentTemplates))
+		}
+
+		if !reflect.DeepEqual(*actual.ComponentTemplates[0], expectedComponentTemplate) {
+			t.Errorf("actual %v\nwant %v", *actual.ComponentTemplates[0], expectedComponentTemplate)
+		}
+
+		if !reflect.DeepEqual(actual.IndexTemplates[0].ComposedOf, []string{"base-settings"}) {
+			t.Errorf("actual %v\nwant %v", actual.IndexTemplates[0].ComposedOf, []string{"base-settings"})
+		}
+	})
+
+	t.Run("Config with index template composed of undefined component", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  delete: 30
+            
+            templates:
+              template-foo:
+                policy: "foo"
+                patterns: [ "index-foo-*" ]
+                composed_of: [ "undefined-component" ]
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+			t.Fatal("undefined component template should fail")
+		}
+	})
+	t.Run("Config with index template settings, mappings and aliases", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  delete: 30
+            
+            templates:
+              template-foo:
+                policy: "foo"
+                patterns: [ "index-foo-*" ]
+                settings:
+                  number_of_shards: 3
+                  refresh_interval: "30s"
+                mappings:
+                  dynamic_templates:
+                    - strings_as_keywords:
+                        match_mapping_type: "string"
+                        mapping:
+                          type: "keyword"
+                  runtime:
+                    day_of_week:
+                      type: "keyword"
+                aliases:
+                  foo: {}
+        `
+
+		expectedIndexTemplate := resource.IndexTemplate{
+			Name:          "template-foo",
+			Patterns:      []string{"index-foo-*"},
+			IlmPolicyName: "foo",
+			Settings: resource.Settings{
+				"number_of_shards": 3,
+				"refresh_interval": "30s",
+			},
+			Mappings: map[string]any{
+				"dynamic_templates": []any{
+					map[string]any{
+						"strings_as_keywords": map[string]any{
+							"match_mapping_type": "string",
+							"mapping":            map[string]any{"type": "keyword"},
+						},
+					},
+				},
+				"runtime": map[string]any{
+					"day_of_week": map[string]any{"type": "keyword"},
+				},
+			},
+			Aliases: map[string]any{
+				"foo": map[string]any{},
+			},
+		}
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(*actual.IndexTemplates[0], expectedIndexTemplate) {
+			t.Errorf("actual %v\nwant %v", *actual.IndexTemplates[0], expectedIndexTemplate)
+		}
+	})
+
+	t.Run("Config with index template lifecycle name in settings", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  delete: 30
+            
+            templates:
+              template-foo:
+                policy: "foo"
+                patterns: [ "index-foo-*" ]
+                settings:
+                  index.lifecycle.name: "bar"
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+			t.Fatal("lifecycle name in index template settings should fail")
+		}
+	})
+	t.Run("Config with overlapping index templates with different priorities", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  delete: 30
+            
+            templates:
+              template-foo:
+                policy: "foo"
+                patterns: [ "index-*" ]
+                priority: 100
+                version: 2
+                _meta:
+                  owner: "team-foo"
+              template-bar:
+                policy: "foo"
+                patterns: [ "index-bar-*" ]
+                priority: 200
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, indexTemplate := range actual.IndexTemplates {
+			if indexTemplate.Name != "template-foo" {
+				continue
+			}
+
+			if *indexTemplate.Priority != 100 || *indexTemplate.Version != 2 || indexTemplate.Meta["owner"] != "team-foo" {
+				t.Errorf("unexpected priority, version or meta: %v", indexTemplate)
+			}
+		}
+	})
+
+	t.Run("Config with overlapping index templates with the same priority", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  delete: 30
+            
+            templates:
+              template-foo:
+                policy: "foo"
+                patterns: [ "index-*" ]
+              template-bar:
+                policy: "foo"
+                patterns: [ "index-bar-*" ]
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+			t.Fatal("overlapping index templates with the same priority should fail")
+		}
+	})
+	t.Run("Config with data stream index templates", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  hot:
+                    rollover:
+                      max_primary_shard_size: "50gb"
+            
+            templates:
+              template-logs:
+                policy: "foo"
+                patterns: [ "logs-*" ]
+                priority: 100
+                dataStream: true
+              template-metrics:
+                policy: "foo"
+                patterns: [ "metrics-*" ]
+                priority: 100
+                dataStream:
+                  hidden: true
+                  allow_custom_routing: true
+                  create: [ "metrics-app-default" ]
+        `
+
+		expectedDataStreams := map[string]*resource.IndexTemplateDataStream{
+			"template-logs": {},
+			"template-metrics": {
+				Hidden:             true,
+				AllowCustomRouting: true,
+				Create:             []string{"metrics-app-default"},
+			},
+		}
+
+		expectedDataStream := &resource.DataStream{
+			Name:          "metrics-app-default",
+			IndexTemplate: "template-metrics",
+		}
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, indexTemplate := range actual.IndexTemplates {
+			if !reflect.DeepEqual(indexTemplate.DataStream, expectedDataStreams[indexTemplate.Name]) {
+				t.Errorf("actual %v\nwant %v", indexTemplate.DataStream, expectedDataStreams[indexTemplate.Name])
+			}
+		}
+
+		if len(actual.DataStreams) != 1 || !reflect.DeepEqual(actual.DataStreams[0], expectedDataStream) {
+			t.Errorf("actual %v\nwant %v", actual.DataStreams, expectedDataStream)
+		}
+	})
+
+	t.Run("Config with disabled data stream", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  delete: 30
+            
+            templates:
+              template-logs:
+                policy: "foo"
+                patterns: [ "logs-*" ]
+                dataStream: false
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if actual.IndexTemplates[0].DataStream != nil {
+			t.Errorf("expected data stream to be disabled")
+		}
+	})
+
+	t.Run("Config with data stream index template without rollover", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  delete: 30
+            
+            templates:
+              template-logs:
+                policy: "foo"
+                patterns: [ "logs-*" ]
+                dataStream: true
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+			t.Fatal("data stream index template without hot phase rollover should fail")
+		}
+	})
+
+	t.Run("Config with data stream not matching index template patterns", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  hot:
+                    rollover:
+                      max_age: "1d"
+            
+            templates:
+              template-logs:
+                policy: "foo"
+                patterns: [ "logs-*" ]
+                dataStream:
+                  create: [ "metrics-app" ]
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+			t.Fatal("data stream not matching index template patterns should fail")
+		}
+	})
+	t.Run("Config with rollover alias", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  hot:
+                    rollover:
+                      max_age: "1d"
+            
+            templates:
+              template-logs:
+                policy: "foo"
+                patterns: [ "logs-*" ]
+                rolloverAlias: "logs"
+        `
+
+		expected := []*resource.BootstrapIndex{
+			{
+				Name:          "logs-000001",
+				Alias:         "logs",
+				IndexTemplate: "template-logs",
+			},
+		}
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if actual.IndexTemplates[0].RolloverAlias != "logs" {
+			t.Errorf("actual %v\nwant %v", actual.IndexTemplates[0].RolloverAlias, "logs")
+		}
+
+		if !reflect.DeepEqual(actual.BootstrapIndices, expected) {
+			t.Errorf("actual %v\nwant %v", actual.BootstrapIndices, expected)
+		}
+	})
+
+	for name, template := range map[string]string{
+		"without hot phase rollover": `
+              template-logs:
+                policy: "bar"
+                patterns: [ "logs-*" ]
+                rolloverAlias: "logs"`,
+		"with bootstrap index not matching patterns": `
+              template-logs:
+                policy: "foo"
+                patterns: [ "app-*" ]
+                rolloverAlias: "logs"`,
+		"with alias matching patterns": `
+              template-logs:
+                policy: "foo"
+                patterns: [ "logs*" ]
+                rolloverAlias: "logs"`,
+		"with data stream": `
+              template-logs:
+                policy: "foo"
+                patterns: [ "logs-*" ]
+                rolloverAlias: "logs"
+                dataStream: true`,
+		"with rollover alias in settings": `
+              template-logs:
+                policy: "foo"
+                patterns: [ "logs-*" ]
+                settings:
+                  index.lifecycle.rollover_alias: "logs"`,
+	} {
+		t.Run("Config with rollover alias "+name, func(t *testing.T) {
+			yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  hot:
+                    rollover:
+                      max_age: "1d"
+              bar:
+                phases:
+                  delete: 30
+            
+            templates:` + template
+
+			tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(tmpFile.Name())
+
+			if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+				t.Fatal("invalid rollover alias config should fail")
+			}
+		})
+	}
+	t.Run("Config with ingest pipelines", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            pipelines:
+              parse:
+                description: "Parse logs"
+                version: 1
+                processors:
+                  - dissect:
+                      field: "message"
+                      pattern: "%{level} %{msg}"
+                on_failure:
+                  - set:
+                      field: "error.message"
+                      value: "{{ _ingest.on_failure_message }}"
+            
+            policies:
+              foo:
+                phases:
+                  delete: 30
+            
+            templates:
+              template-logs:
+                policy: "foo"
+                patterns: [ "logs-*" ]
+                defaultPipeline: "parse"
+                finalPipeline: "parse"
+        `
+
+		version := 1
+		expected := []*resource.IngestPipeline{
+			{
+				Name:        "parse",
+				Description: "Parse logs",
+				Processors: []map[string]any{
+					{"dissect": map[string]any{"field": "message", "pattern": "%{level} %{msg}"}},
+				},
+				OnFailure: []map[string]any{
+					{"set": map[string]any{"field": "error.message", "value": "{{ _ingest.on_failure_message }}"}},
+				},
+				Version: &version,
+			},
+		}
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(actual.IngestPipelines, expected) {
+			t.Errorf("actual %v\nwant %v", actual.IngestPipelines, expected)
+		}
+
+		if actual.IndexTemplates[0].DefaultPipeline != "parse" || actual.IndexTemplates[0].FinalPipeline != "parse" {
+			t.Errorf("unexpected index template pipelines %v", actual.IndexTemplates[0])
+		}
+	})
+
+	for name, config := range map[string]string{
+		"with undefined default pipeline": `
+            templates:
+              template-logs:
+                policy: "foo"
+                patterns: [ "logs-*" ]
+                defaultPipeline: "missing"`,
+		"with undefined final pipeline": `
+            templates:
+              template-logs:
+                policy: "foo"
+                patterns: [ "logs-*" ]
+                finalPipeline: "missing"`,
+		"with pipeline option and setting": `
+            templates:
+              template-logs:
+                policy: "foo"
+                patterns: [ "logs-*" ]
+                defaultPipeline: "parse"
+                settings:
+                  index.default_pipeline: "parse"`,
+		"with empty pipeline processors": `
+              empty:
+                description: "No processors"`,
+	} {
+		t.Run("Config "+name, func(t *testing.T) {
+			yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  delete: 30
+            
+            pipelines:
+              parse:
+                processors:
+                  - lowercase:
+                      field: "message"` + config
+
+			tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(tmpFile.Name())
+
+			if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+				t.Fatal("invalid ingest pipeline config should fail")
+			}
+		})
+	}
+	t.Run("Config with SLM policies", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            slm:
+              nightly-snapshots:
+                schedule: "0 30 1 * * ?"
+                snapshotName: "<nightly-snap-{now/d}>"
+                repository: "backups"
+                indices: [ "logs-*" ]
+                retention:
+                  expire_after: 30
+                  min_count: 5
+                  max_count: 50
+            
+            policies:
+              foo:
+                phases:
+                  delete:
+                    min_age: 90
+                    wait_for_snapshot:
+                      policy: "nightly-snapshots"
+        `
+
+		minCount, maxCount := uint(5), uint(50)
+		expected := []*resource.SlmPolicy{
+			{
+				Name:         "nightly-snapshots",
+				Schedule:     "0 30 1 * * ?",
+				SnapshotName: "<nightly-snap-{now/d}>",
+				Repository:   "backups",
+				Indices:      []string{"logs-*"},
+				Retention: &resource.SlmPolicyRetention{
+					ExpireAfter: "30",
+					MinCount:    &minCount,
+					MaxCount:    &maxCount,
+				},
+			},
+		}
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(actual.SlmPolicies, expected) {
+			t.Errorf("actual %v\nwant %v", actual.SlmPolicies, expected)
+		}
+	})
+
+	for name, config := range map[string]string{
+		"with invalid SLM schedule": `
+            slm:
+              nightly-snapshots:
+                schedule: "0 30 25 * * ?"
+                snapshotName: "<nightly-snap-{now/d}>"
+                repository: "backups"`,
+		"with SLM policy without repository": `
+            slm:
+              nightly-snapshots:
+                schedule: "0 30 1 * * ?"
+                snapshotName: "<nightly-snap-{now/d}>"`,
+		"with SLM retention min_count greater than max_count": `
+            slm:
+              nightly-snapshots:
+                schedule: "0 30 1 * * ?"
+                snapshotName: "<nightly-snap-{now/d}>"
+                repository: "backups"
+                retention:
+                  min_count: 10
+                  max_count: 5`,
+		"with wait_for_snapshot on undefined SLM policy": `
+            slm:
+              weekly-snapshots:
+                schedule: "0 0 2 ? * SUN"
+                snapshotName: "<weekly-snap-{now/d}>"
+                repository: "backups"`,
+	} {
+		t.Run("Config "+name, func(t *testing.T) {
+			yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            policies:
+              foo:
+                phases:
+                  delete:
+                    min_age: 90
+                    wait_for_snapshot:
+                      policy: "nightly-snapshots"
+            ` + config
+
+			tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(tmpFile.Name())
+
+			if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+				t.Fatal("invalid SLM policy config should fail")
+			}
+		})
+	}
+	t.Run("Config with snapshot repositories", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            repositories:
+              backups:
+                type: "fs"
+                settings:
+                  location: "/mnt/backups"
+                  compress: true
+              archive:
+                type: "s3"
+                verify: false
+                settings:
+                  bucket: "archive"
+        `
+
+		verify := false
+		expected := map[string]*resource.SnapshotRepository{
+			"backups": {
+				Name:     "backups",
+				Type:     "fs",
+				Settings: resource.Settings{"location": "/mnt/backups", "compress": true},
+			},
+			"archive": {
+				Name:     "archive",
+				Type:     "s3",
+				Settings: resource.Settings{"bucket": "archive"},
+				Verify:   &verify,
+			},
+		}
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(actual.SnapshotRepositories) != len(expected) {
+			t.Fatalf("actual %v\nwant %v", actual.SnapshotRepositories, expected)
+		}
+
+		for _, repository := range actual.SnapshotRepositories {
+			if !reflect.DeepEqual(repository, expected[repository.Name]) {
+				t.Errorf("actual %v\nwant %v", repository, expected[repository.Name])
+			}
+		}
+	})
+
+	t.Run("Config with invalid snapshot repository", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            repositories:
+              backups:
+                type: "fs"
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+			t.Fatal("fs repository without location should fail")
+		}
+	})
+	t.Run("Config resources are ordered by dependencies", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            templates:
+              template-b:
+                policy: "policy-b"
+                patterns: [ "b-*" ]
+                composed_of: [ "component-a" ]
+                defaultPipeline: "pipeline-a"
+                rolloverAlias: "b"
+              template-a:
+                policy: "policy-a"
+                patterns: [ "a-*" ]
+                dataStream:
+                  create: [ "a-default" ]
+            
+            components:
+              component-a:
+                settings:
+                  number_of_shards: 1
+            
+            policies:
+              policy-b:
+                phases:
+                  hot:
+                    rollover:
+                      max_age: "1d"
+              policy-a:
+                phases:
+                  hot:
+                    rollover:
+                      max_age: "1d"
+                  delete:
+                    min_age: 30
+                    wait_for_snapshot:
+                      policy: "nightly"
+            
+            pipelines:
+              pipeline-a:
+                processors:
+                  - pipeline:
+                      name: "pipeline-b"
+              pipeline-b:
+                processors:
+                  - lowercase:
+                      field: "message"
+            
+            slm:
+              nightly:
+                schedule: "0 30 1 * * ?"
+                snapshotName: "<nightly-{now/d}>"
+                repository: "backups"
+            
+            repositories:
+              backups:
+                type: "fs"
+                settings:
+                  location: "/mnt/backups"
+        `
+
+		expected := []string{
+			"snapshot repository [backups]",
+			"SLM policy [nightly]",
+			"ingest pipeline [pipeline-b]",
+			"ingest pipeline [pipeline-a]",
+			"ILM policy [policy-a]",
+			"ILM policy [policy-b]",
+			"component template [component-a]",
+			"index template [template-a]",
+			"index template [template-b]",
+			"data stream [a-default]",
+			"bootstrap index [b-000001]",
+		}
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		config, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resources, err := config.Resources()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var actual []string
+		for _, node := range resources {
+			actual = append(actual, fmt.Sprintf("%s [%s]", node.Kind, node.Name))
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("actual %v\nwant %v", actual, expected)
+		}
+	})
+
+	t.Run("Config with ingest pipelines dependency cycle", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+            
+            pipelines:
+              pipeline-a:
+                processors:
+                  - pipeline:
+                      name: "pipeline-b"
+              pipeline-b:
+                processors:
+                  - pipeline:
+                      name: "pipeline-a"
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+			t.Fatal("ingest pipelines dependency cycle should fail")
+		}
+	})
+	t.Run("Config with auth types", func(t *testing.T) {
+		for authConfig, expected := range map[string]elk.Auth{
+			`
+              basicAuthToken: "token"`: {Type: elk.AuthBasic, Token: "token"},
+			`
+              basicAuthToken: "token"
+              auth:
+                type: "basic"`: {Type: elk.AuthBasic, Token: "token"},
+			`
+              auth:
+                type: "apiKey"
+                apiKey: "id:key"`: {Type: elk.AuthApiKey, Token: "id:key"},
+			`
+              auth:
+                type: "bearer"
+                token: "bearer-token"`: {Type: elk.AuthBearer, Token: "bearer-token"},
+			`
+              auth:
+                type: "none"`: {Type: elk.AuthNone},
+			`
+              username: "elastic"
+              password: "changeme"`: {Type: elk.AuthBasic, Username: "elastic", Password: "changeme"},
+		} {
+			yamlConfig := `
+            elasticsearch:
+              host: "host"` + authConfig
+
+			tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(tmpFile.Name())
+
+			if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+				t.Fatal(err)
+			}
+
+			actual, err := ReadConfigFromFile(tmpFile.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(actual.Auth, expected) {
+				t.Errorf("actual %v\nwant %v", actual.Auth, expected)
+			}
+		}
+	})
+
+	t.Run("Config with invalid auth", func(t *testing.T) {
+		for _, authConfig := range []string{
+			`
+              auth:
+                type: "apiKey"`,
+			`
+              auth:
+                type: "bearer"`,
+			`
+              auth:
+                type: "kerberos"`,
+			`
+              basicAuthToken: "token"
+              username: "elastic"
+              password: "changeme"`,
+			`
+              username: "elastic"`,
+			`
+              password: "changeme"`,
+			`
+              username: "elastic"
+              password: "changeme"
+              auth:
+                type: "bearer"
+                token: "token"`,
+		} {
+			yamlConfig := `
+            elasticsearch:
+              host: "host"` + authConfig
+
+			tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(tmpFile.Name())
+
+			if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+				t.Errorf("invalid auth config should fail: %s", authConfig)
+			}
+		}
+	})
+
+	t.Run("Config with TLS", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "https://host:9200"
+              basicAuthToken: "token"
+              tls:
+                caFile: "/etc/polyroll/ca.crt"
+                certFile: "/etc/polyroll/client.crt"
+                keyFile: "/etc/polyroll/client.key"
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := elk.TLS{
+			CAFile:   "/etc/polyroll/ca.crt",
+			CertFile: "/etc/polyroll/client.crt",
+			KeyFile:  "/etc/polyroll/client.key",
+		}
+
+		if !reflect.DeepEqual(actual.TLS, expected) {
+			t.Errorf("actual %v\nwant %v", actual.TLS, expected)
+		}
+	})
+
+	t.Run("Config with invalid TLS", func(t *testing.T) {
+		for _, tlsConfig := range []string{
+			`
+                certFile: "/etc/polyroll/client.crt"`,
+			`
+                keyFile: "/etc/polyroll/client.key"`,
+			`
+                caFile: "/etc/polyroll/ca.crt"
+                insecureSkipVerify: true`,
+			`
+                caFile: "/etc/polyroll/ca.crt"
+                caFingerprint: "` + strings.Repeat("ab", 32) + `"`,
+			`
+                caFingerprint: "` + strings.Repeat("ab", 32) + `"
+                insecureSkipVerify: true`,
+			`
+                caFingerprint: "not-a-fingerprint"`,
+		} {
+			yamlConfig := `
+            elasticsearch:
+              host: "https://host:9200"
+              basicAuthToken: "token"
+              tls:` + tlsConfig
+
+			tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(tmpFile.Name())
+
+			if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+				t.Errorf("invalid TLS config should fail: %s", tlsConfig)
+			}
+		}
+	})
+
+	t.Run("Config with timeout and retry", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"
+              timeout: "30s"
+              retry:
+                maxRetries: 0
+                initialBackoff: "200ms"
+                maxBackoff: "5s"
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if actual.Timeout != 30*time.Second {
+			t.Errorf("actual %v\nwant %v", actual.Timeout, 30*time.Second)
+		}
+
+		expected := elk.Retry{MaxRetries: 0, InitialBackoff: 200 * time.Millisecond, MaxBackoff: 5 * time.Second}
+		if !reflect.DeepEqual(actual.Retry, expected) {
+			t.Errorf("actual %v\nwant %v", actual.Retry, expected)
+		}
+	})
+
+	t.Run("Config with invalid timeout and retry", func(t *testing.T) {
+		for _, clientConfig := range []string{
+			`
+              timeout: "soon"`,
+			`
+              timeout: 30`,
+			`
+              timeout: "1d"`,
+			`
+              retry:
+                maxRetries: -1`,
+			`
+              retry:
+                initialBackoff: 500`,
+			`
+              retry:
+                maxBackoff: 10`,
+			`
+              retry:
+                initialBackoff: "1x"`,
+			`
+              retry:
+                initialBackoff: "10s"
+                maxBackoff: "1s"`,
+		} {
+			yamlConfig := `
+            elasticsearch:
+              host: "host"
+              basicAuthToken: "token"` + clientConfig
+
+			tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(tmpFile.Name())
+
+			if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+				t.Errorf("invalid timeout or retry config should fail: %s", clientConfig)
+			}
+		}
+	})
+
+	t.Run("Config with multiple hosts", func(t *testing.T) {
+		yamlConfig := `
+            elasticsearch:
+              hosts: [ "http://es-1:9200", "http://es-2:9200/" ]
+              hostSelection: "sticky"
+              deadHostCooldown: "1m"
+              basicAuthToken: "token"
+        `
+
+		tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(tmpFile.Name())
+
+		if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := ReadConfigFromFile(tmpFile.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedHosts := []string{"http://es-1:9200/", "http://es-2:9200/"}
+		if !reflect.DeepEqual(actual.ElkHosts, expectedHosts) {
+			t.Errorf("actual %v\nwant %v", actual.ElkHosts, expectedHosts)
+		}
+
+		if actual.HostSelection != elk.HostSelectionSticky {
+			t.Errorf("actual %v\nwant %v", actual.HostSelection, elk.HostSelectionSticky)
+		}
+
+		if actual.DeadHostCooldown != time.Minute {
+			t.Errorf("actual %v\nwant %v", actual.DeadHostCooldown, time.Minute)
+		}
+	})
+
+	t.Run("Config with invalid hosts", func(t *testing.T) {
+		for _, hostsConfig := range []string{
+			`
+              host: "http://es-1:9200"
+              hosts: [ "http://es-2:9200" ]`,
+			`
+              hosts: []`,
+			`
+              hosts: [ "http://es-1:9200", "" ]`,
+			`
+              hosts: [ "http://es-1:9200" ]
+              hostSelection: "random"`,
+			`
+              hosts: [ "http://es-1:9200" ]
+              deadHostCooldown: "soon"`,
+			`
+              hosts: [ "http://es-1:9200" ]
+              deadHostCooldown: 30`,
+		} {
+			yamlConfig := `
+            elasticsearch:
+              basicAuthToken: "token"` + hostsConfig
+
+			tmpFile, err := os.CreateTemp("", "tmp_config.yml")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(tmpFile.Name())
+
+			if _, err := tmpFile.Write([]byte(yamlConfig)); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := ReadConfigFromFile(tmpFile.Name()); err == nil {
+				t.Errorf("invalid hosts config should fail: %s", hostsConfig)
+			}
+		}
+	})
 }
@@ -0,0 +1,128 @@
+package diff
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+type Action string
+
+const (
+	Create    Action = "create"
+	Update    Action = "update"
+	Unchanged Action = "unchanged"
+	Orphan    Action = "orphan"
+)
+
+type Change struct {
+	Path    string
+	Current any
+	Desired any
+}
+
+type Result struct {
+	Action  Action
+	Changes []Change
+}
+
+func Compare(current any, desired any) (*Result, error) {
+	normalizedCurrent, err := normalize(current)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedDesired, err := normalize(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := compare("", normalizedCurrent, normalizedDesired)
+
+	if normalizedCurrent == nil {
+		return &Result{Action: Create, Changes: changes}, nil
+	}
+
+	if len(changes) == 0 {
+		return &Result{Action: Unchanged}, nil
+	}
+
+	return &Result{Action: Update, Changes: changes}, nil
+}
+
+func normalize(v any) (any, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized any
+	if err := json.Unmarshal(encoded, &normalized); err != nil {
+		return nil, err
+	}
+
+	return normalized, nil
+}
+
+func compare(path string, current any, desired any) []Change {
+	if isEmpty(current) && isEmpty(desired) {
+		return nil
+	}
+
+	currentMap, currentIsMap := current.(map[string]any)
+	desiredMap, desiredIsMap := desired.(map[string]any)
+
+	if (currentIsMap || current == nil) && (desiredIsMap || desired == nil) {
+		var changes []Change
+		for _, key := range sortedKeys(currentMap, desiredMap) {
+			changes = append(changes, compare(joinPath(path, key), currentMap[key], desiredMap[key])...)
+		}
+
+		return changes
+	}
+
+	if reflect.DeepEqual(current, desired) {
+		return nil
+	}
+
+	return []Change{{Path: path, Current: current, Desired: desired}}
+}
+
+func isEmpty(v any) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(value) == 0
+	case []any:
+		return len(value) == 0
+	}
+
+	return false
+}
+
+func sortedKeys(maps ...map[string]any) []string {
+	seen := map[string]bool{}
+	var keys []string
+
+	for _, m := range maps {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
@@ -0,0 +1,99 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	t.Run("Missing current value results in create", func(t *testing.T) {
+		expected := &Result{
+			Action: Create,
+			Changes: []Change{
+				{Path: "policy.phases.hot.min_age", Current: nil, Desired: "0ms"},
+			},
+		}
+
+		desired := map[string]any{
+			"policy": map[string]any{
+				"phases": map[string]any{
+					"hot": map[string]any{"min_age": "0ms"},
+				},
+			},
+		}
+
+		actual, err := Compare(nil, desired)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("actual %v\nwant %v", actual, expected)
+		}
+	})
+
+	t.Run("Equal values result in unchanged", func(t *testing.T) {
+		value := map[string]any{
+			"index_patterns": []string{"foo-*"},
+			"template":       map[string]any{},
+		}
+
+		actual, err := Compare(value, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if actual.Action != Unchanged || len(actual.Changes) != 0 {
+			t.Errorf("actual %v\nwant unchanged without changes", actual)
+		}
+	})
+
+	t.Run("Empty and missing values are equal", func(t *testing.T) {
+		current := map[string]any{"actions": map[string]any{"delete": map[string]any{}}, "composed_of": []string{}}
+		desired := map[string]any{"actions": map[string]any{"delete": nil}}
+
+		actual, err := Compare(current, desired)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if actual.Action != Unchanged {
+			t.Errorf("actual %v\nwant %v", actual.Action, Unchanged)
+		}
+	})
+
+	t.Run("Different values result in field level update", func(t *testing.T) {
+		expected := &Result{
+			Action: Update,
+			Changes: []Change{
+				{Path: "patterns", Current: []any{"a"}, Desired: []any{"a", "b"}},
+				{Path: "phases.cold.min_age", Current: "30d", Desired: nil},
+				{Path: "phases.warm.min_age", Current: "1d", Desired: "2d"},
+			},
+		}
+
+		current := map[string]any{
+			"phases": map[string]any{
+				"warm": map[string]any{"min_age": "1d"},
+				"cold": map[string]any{"min_age": "30d"},
+			},
+			"patterns": []string{"a"},
+		}
+
+		desired := map[string]any{
+			"phases": map[string]any{
+				"warm": map[string]any{"min_age": "2d"},
+			},
+			"patterns": []string{"a", "b"},
+		}
+
+		actual, err := Compare(current, desired)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("actual %v\nwant %v", actual, expected)
+		}
+	})
+}
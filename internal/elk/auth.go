@@ -0,0 +1,41 @@
+package elk
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+const (
+	AuthBasic  = "basic"
+	AuthApiKey = "apiKey"
+	AuthBearer = "bearer"
+	AuthNone   = "none"
+)
+
+type Auth struct {
+	Type     string
+	Token    string
+	Username string
+	Password string
+}
+
+func (a Auth) header() string {
+	switch a.Type {
+	case AuthBasic:
+		if a.Username != "" {
+			return "Basic " + base64.StdEncoding.EncodeToString([]byte(a.Username+":"+a.Password))
+		}
+
+		return "Basic " + a.Token
+	case AuthApiKey:
+		if strings.Contains(a.Token, ":") {
+			return "ApiKey " + base64.StdEncoding.EncodeToString([]byte(a.Token))
+		}
+
+		return "ApiKey " + a.Token
+	case AuthBearer:
+		return "Bearer " + a.Token
+	}
+
+	return ""
+}
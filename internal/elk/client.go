@@ -5,14 +5,25 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/mihai-valentin/polyroll/internal/diff"
 	"github.com/mihai-valentin/polyroll/internal/resource"
 	"io"
+	"log"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
 	"time"
 )
 
-const createOrUpdateIlmPolicyEndpoint = "_ilm/policy/"
-const createOrUpdateIndexTemplateEndpoint = "_index_template/"
+const ilmPolicyEndpoint = "_ilm/policy"
+const indexTemplateEndpoint = "_index_template"
+const componentTemplateEndpoint = "_component_template"
+const dataStreamEndpoint = "_data_stream"
+const aliasEndpoint = "_alias"
+const ingestPipelineEndpoint = "_ingest/pipeline"
+const slmPolicyEndpoint = "_slm/policy"
+const snapshotRepositoryEndpoint = "_snapshot"
 
 type HttpClient interface {
 	Do(req *http.Request) (*http.Response, error)
@@ -20,88 +31,684 @@ type HttpClient interface {
 
 type Client struct {
 	HttpClient
-	baseURL   string
-	authToken string
+	hosts *hostPool
+	auth  Auth
+	retry Retry
+	sleep func(time.Duration)
 }
 
-func NewElkClient(baseUrl string, basicAuthToken string) *Client {
+type Options struct {
+	Auth             Auth
+	TLS              TLS
+	Timeout          time.Duration
+	Retry            Retry
+	HostSelection    string
+	DeadHostCooldown time.Duration
+}
+
+func NewElkClient(hosts []string, options Options) (*Client, error) {
+	if len(hosts) == 0 {
+		return nil, errors.New("at least one ELK host is required")
+	}
+
+	tlsConfig, err := options.TLS.config()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	cooldown := options.DeadHostCooldown
+	if cooldown <= 0 {
+		cooldown = DefaultDeadHostCooldown
+	}
+
 	return &Client{
 		HttpClient: &http.Client{
-			Timeout: 1 * time.Second,
+			Timeout:   timeout,
+			Transport: transport,
 		},
-		baseURL:   baseUrl,
-		authToken: basicAuthToken,
+		hosts: newHostPool(hosts, options.HostSelection, cooldown),
+		auth:  options.Auth,
+		retry: options.Retry,
+		sleep: time.Sleep,
+	}, nil
+}
+
+func (c *Client) CreateOrUpdateIlmPolicy(policy *resource.IlmPolicy) (diff.Action, error) {
+	result, err := c.PlanIlmPolicy(policy)
+	if err != nil {
+		return "", err
+	}
+
+	return c.putResource(c.endpoint(ilmPolicyEndpoint, policy.Name), policy.Schema(), result)
+}
+
+func (c *Client) CreateOrUpdateIndexTemplate(indexTemplate *resource.IndexTemplate) (diff.Action, error) {
+	result, err := c.PlanIndexTemplate(indexTemplate)
+	if err != nil {
+		return "", err
 	}
+
+	return c.putResource(c.endpoint(indexTemplateEndpoint, indexTemplate.Name), indexTemplate.Schema(), result)
+}
+
+func (c *Client) CreateOrUpdateComponentTemplate(componentTemplate *resource.ComponentTemplate) (diff.Action, error) {
+	result, err := c.PlanComponentTemplate(componentTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	return c.putResource(c.endpoint(componentTemplateEndpoint, componentTemplate.Name), componentTemplate.Schema(), result)
 }
 
-func (c *Client) CreateOrUpdateIlmPolicy(policy *resource.IlmPolicy) error {
-	endpoint := fmt.Sprintf("%s%s%s", c.baseURL, createOrUpdateIlmPolicyEndpoint, policy.Name)
+func (c *Client) ApplyResource(r any) (diff.Action, error) {
+	switch r := r.(type) {
+	case *resource.SnapshotRepository:
+		return c.CreateOrUpdateSnapshotRepository(r)
+	case *resource.SlmPolicy:
+		return c.CreateOrUpdateSlmPolicy(r)
+	case *resource.IngestPipeline:
+		return c.CreateOrUpdateIngestPipeline(r)
+	case *resource.IlmPolicy:
+		return c.CreateOrUpdateIlmPolicy(r)
+	case *resource.ComponentTemplate:
+		return c.CreateOrUpdateComponentTemplate(r)
+	case *resource.IndexTemplate:
+		return c.CreateOrUpdateIndexTemplate(r)
+	case *resource.DataStream:
+		return c.CreateDataStream(r)
+	case *resource.BootstrapIndex:
+		return c.CreateBootstrapIndex(r)
+	}
 
-	return c.putResource(endpoint, policy.Schema())
+	return "", fmt.Errorf("unsupported resource type %T", r)
 }
 
-func (c *Client) CreateOrUpdateIndexTemplate(indexTemplate *resource.IndexTemplate) error {
-	endpoint := fmt.Sprintf("%s%s%s", c.baseURL, createOrUpdateIndexTemplateEndpoint, indexTemplate.Name)
+func (c *Client) PlanResource(r any) (*diff.Result, error) {
+	switch r := r.(type) {
+	case *resource.SnapshotRepository:
+		return c.PlanSnapshotRepository(r)
+	case *resource.SlmPolicy:
+		return c.PlanSlmPolicy(r)
+	case *resource.IngestPipeline:
+		return c.PlanIngestPipeline(r)
+	case *resource.IlmPolicy:
+		return c.PlanIlmPolicy(r)
+	case *resource.ComponentTemplate:
+		return c.PlanComponentTemplate(r)
+	case *resource.IndexTemplate:
+		return c.PlanIndexTemplate(r)
+	case *resource.DataStream:
+		return c.PlanDataStream(r)
+	case *resource.BootstrapIndex:
+		return c.PlanBootstrapIndex(r)
+	}
 
-	return c.putResource(endpoint, indexTemplate.Schema())
+	return nil, fmt.Errorf("unsupported resource type %T", r)
 }
 
-func (c *Client) putResource(endpoint string, schema any) error {
-	jsonSchema, err := json.Marshal(schema)
+func (c *Client) CreateOrUpdateSnapshotRepository(repository *resource.SnapshotRepository) (diff.Action, error) {
+	result, err := c.PlanSnapshotRepository(repository)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	endpoint := c.endpoint(snapshotRepositoryEndpoint, repository.Name)
+	if !repository.ShouldVerify() {
+		endpoint += "?verify=false"
+	}
+
+	return c.putResource(endpoint, repository.Schema(), result)
+}
+
+func (c *Client) CreateOrUpdateSlmPolicy(policy *resource.SlmPolicy) (diff.Action, error) {
+	result, err := c.PlanSlmPolicy(policy)
+	if err != nil {
+		return "", err
+	}
+
+	return c.putResource(c.endpoint(slmPolicyEndpoint, policy.Name), policy.Schema(), result)
+}
+
+func (c *Client) CreateOrUpdateIngestPipeline(pipeline *resource.IngestPipeline) (diff.Action, error) {
+	result, err := c.PlanIngestPipeline(pipeline)
+	if err != nil {
+		return "", err
+	}
+
+	return c.putResource(c.endpoint(ingestPipelineEndpoint, pipeline.Name), pipeline.Schema(), result)
+}
+
+func (c *Client) CreateDataStream(dataStream *resource.DataStream) (diff.Action, error) {
+	result, err := c.PlanDataStream(dataStream)
+	if err != nil {
+		return "", err
+	}
+
+	return c.putResource(c.endpoint(dataStreamEndpoint, dataStream.Name), nil, result)
+}
+
+func (c *Client) CreateBootstrapIndex(bootstrapIndex *resource.BootstrapIndex) (diff.Action, error) {
+	result, err := c.PlanBootstrapIndex(bootstrapIndex)
+	if err != nil {
+		return "", err
+	}
+
+	return c.putResource(c.endpoint(bootstrapIndex.Name, ""), bootstrapIndex.Schema(), result)
+}
+
+func (c *Client) PlanIlmPolicy(policy *resource.IlmPolicy) (*diff.Result, error) {
+	current, err := c.GetIlmPolicy(policy.Name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch current ILM policy: %w", err)
+	}
+
+	return diff.Compare(current.Normalize(), policy.Schema().Normalize())
+}
+
+func (c *Client) PlanIndexTemplate(indexTemplate *resource.IndexTemplate) (*diff.Result, error) {
+	current, err := c.GetIndexTemplate(indexTemplate.Name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch current index template: %w", err)
+	}
+
+	desired := indexTemplate.Schema()
+
+	return diff.Compare(current.Normalize(), desired.Normalize())
+}
+
+func (c *Client) PlanComponentTemplate(componentTemplate *resource.ComponentTemplate) (*diff.Result, error) {
+	current, err := c.GetComponentTemplate(componentTemplate.Name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch current component template: %w", err)
+	}
+
+	desired := componentTemplate.Schema()
+
+	return diff.Compare(current.Normalize(), desired.Normalize())
+}
+
+func (c *Client) PlanSnapshotRepository(repository *resource.SnapshotRepository) (*diff.Result, error) {
+	current, err := c.GetSnapshotRepository(repository.Name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch current snapshot repository: %w", err)
+	}
+
+	desired := repository.Schema()
+
+	return diff.Compare(current.Normalize(), desired.Normalize())
+}
+
+func (c *Client) PlanSlmPolicy(policy *resource.SlmPolicy) (*diff.Result, error) {
+	current, err := c.GetSlmPolicy(policy.Name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch current SLM policy: %w", err)
+	}
+
+	return diff.Compare(current, policy.Schema())
+}
+
+func (c *Client) PlanIngestPipeline(pipeline *resource.IngestPipeline) (*diff.Result, error) {
+	current, err := c.GetIngestPipeline(pipeline.Name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch current ingest pipeline: %w", err)
+	}
+
+	return diff.Compare(current, pipeline.Schema())
+}
+
+func (c *Client) PlanDataStream(dataStream *resource.DataStream) (*diff.Result, error) {
+	var response dataStreamResponse
+
+	found, err := c.getResource(c.endpoint(dataStreamEndpoint, dataStream.Name), &response)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch current data stream: %w", err)
+	}
+
+	if found && len(response.DataStreams) > 0 {
+		return &diff.Result{Action: diff.Unchanged}, nil
+	}
+
+	return &diff.Result{Action: diff.Create}, nil
+}
+
+func (c *Client) PlanBootstrapIndex(bootstrapIndex *resource.BootstrapIndex) (*diff.Result, error) {
+	var response map[string]any
+
+	found, err := c.getResource(c.endpoint(aliasEndpoint, bootstrapIndex.Alias), &response)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch current rollover alias: %w", err)
+	}
+
+	if found && len(response) > 0 {
+		return &diff.Result{Action: diff.Unchanged}, nil
+	}
+
+	return &diff.Result{Action: diff.Create}, nil
+}
+
+func (c *Client) GetIlmPolicy(name string) (resource.ImlPolicySchema, error) {
+	var response ilmPolicyResponse
+
+	found, err := c.getResource(c.endpoint(ilmPolicyEndpoint, name), &response)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	policy, ok := response[name]
+	if !ok {
+		return nil, nil
+	}
+
+	return resource.ImlPolicySchema{
+		"policy": {
+			"phases": policy.Policy.Phases,
+		},
+	}, nil
+}
+
+func (c *Client) GetIndexTemplate(name string) (*resource.IndexTemplateSchema, error) {
+	var response indexTemplateResponse
+
+	found, err := c.getResource(c.endpoint(indexTemplateEndpoint, name), &response)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	for _, indexTemplate := range response.IndexTemplates {
+		if indexTemplate.Name == name {
+			return &indexTemplate.IndexTemplate, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *Client) GetComponentTemplate(name string) (*resource.ComponentTemplateSchema, error) {
+	var response componentTemplateResponse
+
+	found, err := c.getResource(c.endpoint(componentTemplateEndpoint, name), &response)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	for _, componentTemplate := range response.ComponentTemplates {
+		if componentTemplate.Name == name {
+			return &componentTemplate.ComponentTemplate, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *Client) GetSnapshotRepository(name string) (*resource.SnapshotRepositorySchema, error) {
+	var response snapshotRepositoryResponse
+
+	found, err := c.getResource(c.endpoint(snapshotRepositoryEndpoint, name), &response)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	repository, ok := response[name]
+	if !ok {
+		return nil, nil
+	}
+
+	return &repository, nil
+}
+
+func (c *Client) GetSlmPolicy(name string) (*resource.SlmPolicySchema, error) {
+	var response slmPolicyResponse
+
+	found, err := c.getResource(c.endpoint(slmPolicyEndpoint, name), &response)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	policy, ok := response[name]
+	if !ok {
+		return nil, nil
+	}
+
+	return &policy.Policy, nil
+}
+
+func (c *Client) GetIngestPipeline(name string) (*resource.IngestPipelineSchema, error) {
+	var response ingestPipelineResponse
+
+	found, err := c.getResource(c.endpoint(ingestPipelineEndpoint, name), &response)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	pipeline, ok := response[name]
+	if !ok {
+		return nil, nil
+	}
+
+	return &pipeline, nil
+}
+
+func (c *Client) ListIlmPolicies() ([]string, error) {
+	var response ilmPolicyResponse
+
+	if _, err := c.getResource(c.endpoint(ilmPolicyEndpoint, ""), &response); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name, policy := range response {
+		if !isManagedResource(name, policy.Policy.Meta) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (c *Client) ListIndexTemplates() ([]string, error) {
+	var response indexTemplateListResponse
+
+	if _, err := c.getResource(c.endpoint(indexTemplateEndpoint, ""), &response); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, indexTemplate := range response.IndexTemplates {
+		if !isManagedResource(indexTemplate.Name, indexTemplate.IndexTemplate.Meta) {
+			names = append(names, indexTemplate.Name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (c *Client) ListComponentTemplates() ([]string, error) {
+	var response componentTemplateListResponse
+
+	if _, err := c.getResource(c.endpoint(componentTemplateEndpoint, ""), &response); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, componentTemplate := range response.ComponentTemplates {
+		if !isManagedResource(componentTemplate.Name, componentTemplate.ComponentTemplate.Meta) {
+			names = append(names, componentTemplate.Name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (c *Client) ListSnapshotRepositories() ([]string, error) {
+	var response snapshotRepositoryResponse
+
+	if _, err := c.getResource(c.endpoint(snapshotRepositoryEndpoint, ""), &response); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name := range response {
+		if !isManagedResource(name, nil) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (c *Client) ListSlmPolicies() ([]string, error) {
+	var response slmPolicyListResponse
+
+	if _, err := c.getResource(c.endpoint(slmPolicyEndpoint, ""), &response); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name, policy := range response {
+		if !isManagedResource(name, policy.Policy.Meta) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (c *Client) ListIngestPipelines() ([]string, error) {
+	var response ingestPipelineListResponse
+
+	if _, err := c.getResource(c.endpoint(ingestPipelineEndpoint, ""), &response); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name, pipeline := range response {
+		if !isManagedResource(name, pipeline.Meta) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (c *Client) endpoint(path string, name string) string {
+	if name == "" {
+		return path
+	}
+
+	return fmt.Sprintf("%s/%s", path, name)
+}
+
+func (c *Client) getResource(endpoint string, v any) (bool, error) {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := c.do(req)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if err := json.Unmarshal(respBody, v); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *Client) putResource(endpoint string, schema any, result *diff.Result) (diff.Action, error) {
+	if result.Action == diff.Unchanged {
+		return diff.Unchanged, nil
+	}
+
+	var body io.Reader
+	if schema != nil {
+		jsonSchema, err := json.Marshal(schema)
+		if err != nil {
+			return "", err
+		}
+
+		body = bytes.NewBuffer(jsonSchema)
 	}
 
-	body := bytes.NewBuffer(jsonSchema)
 	req, err := http.NewRequest(http.MethodPut, endpoint, body)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	if _, err := c.do(req); err != nil {
-		return err
+	resp, err := c.do(req)
+	if err != nil {
+		return "", err
 	}
 
-	return nil
+	if ok, err := parseAcknowledgmentStatusFromResponse(resp); !ok || err != nil {
+		return "", fmt.Errorf("ELK API call wasn't acknowledged: %w", err)
+	}
+
+	return result.Action, nil
 }
 
 func (c *Client) do(req *http.Request) (*http.Response, error) {
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Basic "+c.authToken)
+	if header := c.auth.header(); header != "" {
+		req.Header.Set("Authorization", header)
+	}
 
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
-		return nil, err
+	path := req.URL.String()
+
+	for attempt, failover := 0, 0; ; {
+		host := c.hosts.next()
+
+		hostURL, err := url.Parse(host.url + path)
+		if err != nil {
+			return nil, err
+		}
+		req.URL = hostURL
+
+		resp, err := c.HttpClient.Do(req)
+		if err != nil {
+			c.hosts.markDead(host)
+
+			if failover < c.hosts.size()-1 {
+				failover++
+
+				log.Printf("ELK host %s is unreachable: %s, trying the next host\n", host.url, err)
+
+				if err := resetRequestBody(req); err != nil {
+					return nil, err
+				}
+
+				continue
+			}
+		} else {
+			c.hosts.markAlive(host)
+		}
+
+		retryable := err != nil || isRetryableStatus(resp.StatusCode)
+		if !retryable || attempt >= c.retry.MaxRetries {
+			if err != nil {
+				return nil, &ConnectionError{Err: err}
+			}
+
+			if resp.StatusCode != 200 {
+				return resp, parseErrorFromResponse(resp)
+			}
+
+			return resp, nil
+		}
+
+		if err := c.waitForRetry(req, resp, err, attempt); err != nil {
+			return nil, err
+		}
+
+		attempt++
+		failover = 0
 	}
+}
 
-	if resp.StatusCode != 200 {
-		responseError := parseErrorFromResponse(resp)
+func (c *Client) waitForRetry(req *http.Request, resp *http.Response, err error, attempt int) error {
+	delay, ok := parseRetryAfter(resp, time.Now())
+	if !ok {
+		delay = c.retry.backoff(attempt)
+	}
 
-		return resp, fmt.Errorf("ELK API call failed with status code %d: %w", resp.StatusCode, responseError)
+	if c.retry.MaxBackoff > 0 && delay > c.retry.MaxBackoff {
+		delay = c.retry.MaxBackoff
 	}
 
-	if ok, err := parseAcknowledgmentStatusFromResponse(resp); !ok || err != nil {
-		return resp, fmt.Errorf("ELK API call wasn't acknowledged: %w", err)
+	reason := fmt.Sprintf("%v", err)
+	if resp != nil {
+		reason = fmt.Sprintf("status code %d", resp.StatusCode)
+
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
 	}
 
-	return resp, nil
+	log.Printf("ELK request %s %s failed with %s, retrying in %s (attempt %d of %d)\n",
+		req.Method,
+		req.URL.Path,
+		reason,
+		delay.Round(time.Millisecond),
+		attempt+1,
+		c.retry.MaxRetries,
+	)
+
+	if c.sleep != nil {
+		c.sleep(delay)
+	} else {
+		time.Sleep(delay)
+	}
+
+	return resetRequestBody(req)
+}
+
+func resetRequestBody(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+
+	req.Body = body
+
+	return nil
 }
 
 func parseErrorFromResponse(resp *http.Response) error {
 	var elkResponse errorResponse
 
+	responseError := &ResponseError{StatusCode: resp.StatusCode}
+
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		responseError.Reason = err.Error()
+		return responseError
 	}
 	defer resp.Body.Close()
 
 	if err := json.Unmarshal(respBody, &elkResponse); err != nil {
-		return err
+		responseError.Reason = err.Error()
+		return responseError
 	}
 
-	return errors.New(elkResponse.Error.Reason)
+	responseError.Type = elkResponse.Error.Type
+	responseError.Reason = elkResponse.Error.Reason
+
+	return responseError
 }
 
 func parseAcknowledgmentStatusFromResponse(resp *http.Response) (bool, error) {
@@ -119,3 +726,13 @@ func parseAcknowledgmentStatusFromResponse(resp *http.Response) (bool, error) {
 
 	return elkResponse.Acknowledged, nil
 }
+
+func isManagedResource(name string, meta map[string]any) bool {
+	if strings.HasPrefix(name, ".") {
+		return true
+	}
+
+	managed, _ := meta["managed"].(bool)
+
+	return managed
+}
@@ -2,9 +2,12 @@ package elk
 
 import (
 	"bytes"
+	"errors"
+	"github.com/mihai-valentin/polyroll/internal/diff"
 	"github.com/mihai-valentin/polyroll/internal/resource"
 	"io"
 	"net/http"
+	"reflect"
 	"testing"
 )
 
@@ -12,7 +15,14 @@ type MockedClient struct {
 	*http.Client
 }
 
-func (c *MockedClient) Do(*http.Request) (*http.Response, error) {
+func (c *MockedClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet {
+		return &http.Response{
+			StatusCode: 404,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"error": {"reason": "not found"}, "status": 404}`)),
+		}, nil
+	}
+
 	resp := &http.Response{
 		StatusCode: 200,
 		Body: io.NopCloser(bytes.NewBufferString(`{
@@ -26,21 +36,26 @@ func (c *MockedClient) Do(*http.Request) (*http.Response, error) {
 func TestElkClient_CreateOrUpdateIlmPolicy(t *testing.T) {
 	elkClientWithMockedClient := Client{
 		HttpClient: &MockedClient{},
-		baseURL:    "localhost/",
-		authToken:  "token",
+		hosts:      newHostPool([]string{"localhost/"}, HostSelectionRoundRobin, 0),
+		auth:       Auth{Type: AuthBasic, Token: "token"},
 	}
 
 	t.Run("Create new ILM policy", func(t *testing.T) {
 		policy := &resource.IlmPolicy{
 			Name:   "test-policy",
-			Warm:   1,
-			Cold:   2,
-			Delete: 3,
+			Warm:   &resource.IlmPolicyPhase{MinAge: "1d"},
+			Cold:   &resource.IlmPolicyPhase{MinAge: "2d"},
+			Delete: &resource.IlmPolicyPhase{MinAge: "3d"},
 		}
 
-		if err := elkClientWithMockedClient.CreateOrUpdateIlmPolicy(policy); err != nil {
+		action, err := elkClientWithMockedClient.CreateOrUpdateIlmPolicy(policy)
+		if err != nil {
 			t.Fatalf("Create policy failed: %v", err)
 		}
+
+		if action != diff.Create {
+			t.Errorf("actual %v\nwant %v", action, diff.Create)
+		}
 	})
 
 	t.Run("Create new index template", func(t *testing.T) {
@@ -50,8 +65,645 @@ func TestElkClient_CreateOrUpdateIlmPolicy(t *testing.T) {
 			IlmPolicyName: "test-policy",
 		}
 
-		if err := elkClientWithMockedClient.CreateOrUpdateIndexTemplate(indexTemplate); err != nil {
+		action, err := elkClientWithMockedClient.CreateOrUpdateIndexTemplate(indexTemplate)
+		if err != nil {
 			t.Fatalf("Create index template failed: %v", err)
 		}
+
+		if action != diff.Create {
+			t.Errorf("actual %v\nwant %v", action, diff.Create)
+		}
+	})
+
+	t.Run("Create new component template", func(t *testing.T) {
+		componentTemplate := &resource.ComponentTemplate{
+			Name:     "test-component-template",
+			Settings: resource.Settings{"number_of_shards": 1},
+		}
+
+		action, err := elkClientWithMockedClient.CreateOrUpdateComponentTemplate(componentTemplate)
+		if err != nil {
+			t.Fatalf("Create component template failed: %v", err)
+		}
+
+		if action != diff.Create {
+			t.Errorf("actual %v\nwant %v", action, diff.Create)
+		}
+	})
+
+	t.Run("Create new SLM policy", func(t *testing.T) {
+		slmPolicy := &resource.SlmPolicy{
+			Name:         "nightly-snapshots",
+			Schedule:     "0 30 1 * * ?",
+			SnapshotName: "<nightly-snap-{now/d}>",
+			Repository:   "backups",
+		}
+
+		action, err := elkClientWithMockedClient.CreateOrUpdateSlmPolicy(slmPolicy)
+		if err != nil {
+			t.Fatalf("Create SLM policy failed: %v", err)
+		}
+
+		if action != diff.Create {
+			t.Errorf("actual %v\nwant %v", action, diff.Create)
+		}
+	})
+
+	t.Run("Create new ingest pipeline", func(t *testing.T) {
+		pipeline := &resource.IngestPipeline{
+			Name:       "test-pipeline",
+			Processors: []map[string]any{{"set": map[string]any{"field": "env", "value": "prod"}}},
+		}
+
+		action, err := elkClientWithMockedClient.CreateOrUpdateIngestPipeline(pipeline)
+		if err != nil {
+			t.Fatalf("Create ingest pipeline failed: %v", err)
+		}
+
+		if action != diff.Create {
+			t.Errorf("actual %v\nwant %v", action, diff.Create)
+		}
+	})
+}
+
+type HeaderMockedClient struct {
+	authorization string
+}
+
+func (c *HeaderMockedClient) Do(req *http.Request) (*http.Response, error) {
+	c.authorization = req.Header.Get("Authorization")
+
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"index_templates": []}`)),
+	}, nil
+}
+
+func TestElkClient_Authorization(t *testing.T) {
+	for name, tc := range map[string]struct {
+		auth     Auth
+		expected string
+	}{
+		"basic":           {Auth{Type: AuthBasic, Token: "dXNlcjpwYXNz"}, "Basic dXNlcjpwYXNz"},
+		"basic username":  {Auth{Type: AuthBasic, Username: "user", Password: "pass"}, "Basic dXNlcjpwYXNz"},
+		"api key":         {Auth{Type: AuthApiKey, Token: "id:key"}, "ApiKey aWQ6a2V5"},
+		"encoded api key": {Auth{Type: AuthApiKey, Token: "aWQ6a2V5"}, "ApiKey aWQ6a2V5"},
+		"bearer":          {Auth{Type: AuthBearer, Token: "token"}, "Bearer token"},
+		"none":            {Auth{Type: AuthNone}, ""},
+	} {
+		mockedClient := &HeaderMockedClient{}
+		elkClientWithMockedClient := Client{
+			HttpClient: mockedClient,
+			hosts:      newHostPool([]string{"localhost/"}, HostSelectionRoundRobin, 0),
+			auth:       tc.auth,
+		}
+
+		if _, err := elkClientWithMockedClient.ListIndexTemplates(); err != nil {
+			t.Fatalf("[%s]: list index templates failed: %v", name, err)
+		}
+
+		if mockedClient.authorization != tc.expected {
+			t.Errorf("[%s]: actual %v\nwant %v", name, mockedClient.authorization, tc.expected)
+		}
+	}
+}
+
+type FailingMockedClient struct{}
+
+func (c *FailingMockedClient) Do(req *http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestElkClient_ConnectionError(t *testing.T) {
+	elkClientWithMockedClient := Client{
+		HttpClient: &FailingMockedClient{},
+		hosts:      newHostPool([]string{"localhost/"}, HostSelectionRoundRobin, 0),
+		auth:       Auth{Type: AuthBasic, Token: "token"},
+	}
+
+	_, err := elkClientWithMockedClient.CreateOrUpdateIlmPolicy(&resource.IlmPolicy{Name: "test-policy"})
+
+	var connectionError *ConnectionError
+	if !errors.As(err, &connectionError) {
+		t.Fatalf("expected connection error, got %v", err)
+	}
+}
+
+type mockedResponse struct {
+	statusCode int
+	body       string
+}
+
+type RoutedMockedClient struct {
+	responses map[string]mockedResponse
+	requests  []string
+}
+
+func (c *RoutedMockedClient) Do(req *http.Request) (*http.Response, error) {
+	route := req.Method + " " + req.URL.String()
+	c.requests = append(c.requests, route)
+
+	response, ok := c.responses[route]
+	if !ok {
+		response = mockedResponse{
+			statusCode: 404,
+			body:       `{"error": {"type": "resource_not_found_exception", "reason": "not found"}, "status": 404}`,
+		}
+	}
+
+	return &http.Response{
+		StatusCode: response.statusCode,
+		Body:       io.NopCloser(bytes.NewBufferString(response.body)),
+	}, nil
+}
+
+func TestElkClient_GetResources(t *testing.T) {
+	elkClientWithMockedClient := Client{
+		HttpClient: &RoutedMockedClient{
+			responses: map[string]mockedResponse{
+				"GET localhost/_ilm/policy/test-policy": {200, `{
+                    "test-policy": {
+                        "version": 3,
+                        "modified_date": "2023-01-01T00:00:00.000Z",
+                        "policy": {
+                            "phases": {
+                                "hot": {"min_age": "0ms", "actions": {"set_priority": {"priority": 100}}},
+                                "delete": {"min_age": "30d", "actions": {"delete": {"delete_searchable_snapshot": true}}}
+                            }
+                        }
+                    }
+                }`},
+				"GET localhost/_ilm/policy": {200, `{
+                    "test-policy": {"policy": {"phases": {}}},
+                    "logs": {"policy": {"phases": {}, "_meta": {"managed": true}}},
+                    ".internal-policy": {"policy": {"phases": {}}}
+                }`},
+				"GET localhost/_index_template/test-index-template": {200, `{
+                    "index_templates": [{
+                        "name": "test-index-template",
+                        "index_template": {
+                            "index_patterns": ["pattern"],
+                            "template": {"settings": {"index": {"lifecycle": {"name": "test-policy"}}}},
+                            "composed_of": []
+                        }
+                    }]
+                }`},
+				"GET localhost/_ingest/pipeline": {200, `{
+                    "test-pipeline": {"processors": []},
+                    "xpack_monitoring": {"processors": [], "_meta": {"managed": true}}
+                }`},
+				"GET localhost/_index_template": {200, `{
+                    "index_templates": [
+                        {"name": "test-index-template", "index_template": {"index_patterns": ["pattern"]}},
+                        {"name": "metrics", "index_template": {"index_patterns": ["metrics-*"], "_meta": {"managed": true}}}
+                    ]
+                }`},
+			},
+		},
+		hosts: newHostPool([]string{"localhost/"}, HostSelectionRoundRobin, 0),
+		auth:  Auth{Type: AuthBasic, Token: "token"},
+	}
+
+	t.Run("Get existing ILM policy", func(t *testing.T) {
+		expected := resource.ImlPolicySchema{
+			"policy": {
+				"phases": {
+					"hot": resource.PolicyPhase{
+						MinAge: "0ms",
+						Actions: resource.PolicyPhaseActions{
+							"set_priority": {"priority": float64(100)},
+						},
+					},
+					"delete": resource.PolicyPhase{
+						MinAge: "30d",
+						Actions: resource.PolicyPhaseActions{
+							"delete": {"delete_searchable_snapshot": true},
+						},
+					},
+				},
+			},
+		}
+
+		actual, err := elkClientWithMockedClient.GetIlmPolicy("test-policy")
+		if err != nil {
+			t.Fatalf("Get policy failed: %v", err)
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("actual %v\nwant %v", actual, expected)
+		}
+	})
+
+	t.Run("Get missing ILM policy", func(t *testing.T) {
+		actual, err := elkClientWithMockedClient.GetIlmPolicy("missing-policy")
+		if err != nil {
+			t.Fatalf("Get policy failed: %v", err)
+		}
+
+		if actual != nil {
+			t.Errorf("actual %v\nwant nil", actual)
+		}
+	})
+
+	t.Run("Get existing index template", func(t *testing.T) {
+		expected := (&resource.IndexTemplate{
+			Patterns:      []string{"pattern"},
+			IlmPolicyName: "test-policy",
+			ComposedOf:    []string{},
+		}).Schema()
+
+		actual, err := elkClientWithMockedClient.GetIndexTemplate("test-index-template")
+		if err != nil {
+			t.Fatalf("Get index template failed: %v", err)
+		}
+
+		if actual == nil || !reflect.DeepEqual(*actual, expected) {
+			t.Errorf("actual %v\nwant %v", actual, expected)
+		}
+	})
+
+	t.Run("Get missing index template", func(t *testing.T) {
+		actual, err := elkClientWithMockedClient.GetIndexTemplate("missing-index-template")
+		if err != nil {
+			t.Fatalf("Get index template failed: %v", err)
+		}
+
+		if actual != nil {
+			t.Errorf("actual %v\nwant nil", actual)
+		}
+	})
+
+	t.Run("List unmanaged ILM policies", func(t *testing.T) {
+		actual, err := elkClientWithMockedClient.ListIlmPolicies()
+		if err != nil {
+			t.Fatalf("List policies failed: %v", err)
+		}
+
+		if !reflect.DeepEqual(actual, []string{"test-policy"}) {
+			t.Errorf("actual %v\nwant %v", actual, []string{"test-policy"})
+		}
+	})
+
+	t.Run("List unmanaged ingest pipelines", func(t *testing.T) {
+		actual, err := elkClientWithMockedClient.ListIngestPipelines()
+		if err != nil {
+			t.Fatalf("List ingest pipelines failed: %v", err)
+		}
+
+		if !reflect.DeepEqual(actual, []string{"test-pipeline"}) {
+			t.Errorf("actual %v\nwant %v", actual, []string{"test-pipeline"})
+		}
+	})
+
+	t.Run("List unmanaged index templates", func(t *testing.T) {
+		actual, err := elkClientWithMockedClient.ListIndexTemplates()
+		if err != nil {
+			t.Fatalf("List index templates failed: %v", err)
+		}
+
+		if !reflect.DeepEqual(actual, []string{"test-index-template"}) {
+			t.Errorf("actual %v\nwant %v", actual, []string{"test-index-template"})
+		}
+	})
+}
+
+func TestElkClient_CreateOrUpdateUnchangedResources(t *testing.T) {
+	mockedClient := &RoutedMockedClient{
+		responses: map[string]mockedResponse{
+			"GET localhost/_ilm/policy/test-policy": {200, `{
+                "test-policy": {
+                    "version": 3,
+                    "policy": {
+                        "phases": {
+                            "hot": {"min_age": "0ms", "actions": {"set_priority": {"priority": 100}}},
+                            "warm": {"min_age": "1d", "actions": {"set_priority": {"priority": 50}}}
+                        }
+                    }
+                }
+            }`},
+			"PUT localhost/_ilm/policy/test-policy": {200, `{"acknowledged": true}`},
+			"GET localhost/_data_stream/logs-app-default": {200, `{
+                "data_streams": [{"name": "logs-app-default", "template": "logs-app"}]
+            }`},
+			"PUT localhost/_data_stream/logs-app-new": {200, `{"acknowledged": true}`},
+			"GET localhost/_alias/logs": {200, `{
+                "logs-000001": {"aliases": {"logs": {"is_write_index": true}}}
+            }`},
+			"PUT localhost/metrics-000001": {200, `{"acknowledged": true, "shards_acknowledged": true, "index": "metrics-000001"}`},
+			"GET localhost/_snapshot/backups": {200, `{
+                "backups": {"type": "fs", "settings": {"location": "/mnt/backups", "compress": "true"}}
+            }`},
+			"PUT localhost/_snapshot/archive?verify=false": {200, `{"acknowledged": true}`},
+			"GET localhost/_slm/policy/nightly-snapshots": {200, `{
+                "nightly-snapshots": {
+                    "version": 1,
+                    "modified_date_millis": 1700000000000,
+                    "policy": {
+                        "name": "<nightly-snap-{now/d}>",
+                        "schedule": "0 30 1 * * ?",
+                        "repository": "backups",
+                        "config": {"indices": ["logs-*"]},
+                        "retention": {"expire_after": "30d"}
+                    },
+                    "next_execution_millis": 1700000000000
+                }
+            }`},
+			"GET localhost/_ingest/pipeline/test-pipeline": {200, `{
+                "test-pipeline": {
+                    "description": "Set environment",
+                    "processors": [{"set": {"field": "env", "value": "prod"}}]
+                }
+            }`},
+			"GET localhost/_component_template/test-component-template": {200, `{
+                "component_templates": [{
+                    "name": "test-component-template",
+                    "component_template": {
+                        "template": {"settings": {"index": {"number_of_shards": "1"}}}
+                    }
+                }]
+            }`},
+			"GET localhost/_index_template/test-index-template": {200, `{
+                "index_templates": [{
+                    "name": "test-index-template",
+                    "index_template": {
+                        "index_patterns": ["pattern"],
+                        "template": {"settings": {"index": {"lifecycle": {"name": "test-policy"}}}},
+                        "composed_of": []
+                    }
+                }]
+            }`},
+		},
+	}
+
+	elkClientWithMockedClient := Client{
+		HttpClient: mockedClient,
+		hosts:      newHostPool([]string{"localhost/"}, HostSelectionRoundRobin, 0),
+		auth:       Auth{Type: AuthBasic, Token: "token"},
+	}
+
+	t.Run("Unchanged ILM policy is not updated", func(t *testing.T) {
+		mockedClient.requests = nil
+
+		action, err := elkClientWithMockedClient.CreateOrUpdateIlmPolicy(&resource.IlmPolicy{
+			Name: "test-policy",
+			Warm: &resource.IlmPolicyPhase{MinAge: "1d"},
+		})
+		if err != nil {
+			t.Fatalf("Create policy failed: %v", err)
+		}
+
+		if action != diff.Unchanged {
+			t.Errorf("actual %v\nwant %v", action, diff.Unchanged)
+		}
+
+		if !reflect.DeepEqual(mockedClient.requests, []string{"GET localhost/_ilm/policy/test-policy"}) {
+			t.Errorf("unexpected requests %v", mockedClient.requests)
+		}
+	})
+
+	t.Run("Changed ILM policy is updated", func(t *testing.T) {
+		mockedClient.requests = nil
+
+		action, err := elkClientWithMockedClient.CreateOrUpdateIlmPolicy(&resource.IlmPolicy{
+			Name: "test-policy",
+			Warm: &resource.IlmPolicyPhase{MinAge: "2d"},
+		})
+		if err != nil {
+			t.Fatalf("Create policy failed: %v", err)
+		}
+
+		if action != diff.Update {
+			t.Errorf("actual %v\nwant %v", action, diff.Update)
+		}
+
+		expectedRequests := []string{
+			"GET localhost/_ilm/policy/test-policy",
+			"PUT localhost/_ilm/policy/test-policy",
+		}
+
+		if !reflect.DeepEqual(mockedClient.requests, expectedRequests) {
+			t.Errorf("actual %v\nwant %v", mockedClient.requests, expectedRequests)
+		}
+	})
+
+	t.Run("Unchanged component template is not updated", func(t *testing.T) {
+		mockedClient.requests = nil
+
+		action, err := elkClientWithMockedClient.CreateOrUpdateComponentTemplate(&resource.ComponentTemplate{
+			Name:     "test-component-template",
+			Settings: resource.Settings{"number_of_shards": 1},
+		})
+		if err != nil {
+			t.Fatalf("Create component template failed: %v", err)
+		}
+
+		if action != diff.Unchanged {
+			t.Errorf("actual %v\nwant %v", action, diff.Unchanged)
+		}
+
+		if len(mockedClient.requests) != 1 {
+			t.Errorf("unexpected requests %v", mockedClient.requests)
+		}
+	})
+
+	t.Run("Unchanged snapshot repository is not updated", func(t *testing.T) {
+		mockedClient.requests = nil
+
+		repository := &resource.SnapshotRepository{
+			Name:     "backups",
+			Type:     "fs",
+			Settings: resource.Settings{"location": "/mnt/backups", "compress": true},
+		}
+
+		action, err := elkClientWithMockedClient.CreateOrUpdateSnapshotRepository(repository)
+		if err != nil {
+			t.Fatalf("Create snapshot repository failed: %v", err)
+		}
+
+		if action != diff.Unchanged {
+			t.Errorf("actual %v\nwant %v", action, diff.Unchanged)
+		}
+
+		if !reflect.DeepEqual(mockedClient.requests, []string{"GET localhost/_snapshot/backups"}) {
+			t.Errorf("unexpected requests %v", mockedClient.requests)
+		}
+	})
+
+	t.Run("Snapshot repository is created without verification", func(t *testing.T) {
+		mockedClient.requests = nil
+
+		verify := false
+		repository := &resource.SnapshotRepository{
+			Name:     "archive",
+			Type:     "url",
+			Settings: resource.Settings{"url": "https://example.com/archive"},
+			Verify:   &verify,
+		}
+
+		action, err := elkClientWithMockedClient.CreateOrUpdateSnapshotRepository(repository)
+		if err != nil {
+			t.Fatalf("Create snapshot repository failed: %v", err)
+		}
+
+		if action != diff.Create {
+			t.Errorf("actual %v\nwant %v", action, diff.Create)
+		}
+
+		expectedRequests := []string{
+			"GET localhost/_snapshot/archive",
+			"PUT localhost/_snapshot/archive?verify=false",
+		}
+
+		if !reflect.DeepEqual(mockedClient.requests, expectedRequests) {
+			t.Errorf("actual %v\nwant %v", mockedClient.requests, expectedRequests)
+		}
+	})
+
+	t.Run("Unchanged SLM policy is not updated", func(t *testing.T) {
+		mockedClient.requests = nil
+
+		slmPolicy := &resource.SlmPolicy{
+			Name:         "nightly-snapshots",
+			Schedule:     "0 30 1 * * ?",
+			SnapshotName: "<nightly-snap-{now/d}>",
+			Repository:   "backups",
+			Indices:      []string{"logs-*"},
+			Retention:    &resource.SlmPolicyRetention{ExpireAfter: "30d"},
+		}
+
+		action, err := elkClientWithMockedClient.CreateOrUpdateSlmPolicy(slmPolicy)
+		if err != nil {
+			t.Fatalf("Create SLM policy failed: %v", err)
+		}
+
+		if action != diff.Unchanged {
+			t.Errorf("actual %v\nwant %v", action, diff.Unchanged)
+		}
+
+		if !reflect.DeepEqual(mockedClient.requests, []string{"GET localhost/_slm/policy/nightly-snapshots"}) {
+			t.Errorf("unexpected requests %v", mockedClient.requests)
+		}
+	})
+
+	t.Run("Unchanged ingest pipeline is not updated", func(t *testing.T) {
+		mockedClient.requests = nil
+
+		pipeline := &resource.IngestPipeline{
+			Name:        "test-pipeline",
+			Description: "Set environment",
+			Processors:  []map[string]any{{"set": map[string]any{"field": "env", "value": "prod"}}},
+		}
+
+		action, err := elkClientWithMockedClient.CreateOrUpdateIngestPipeline(pipeline)
+		if err != nil {
+			t.Fatalf("Create ingest pipeline failed: %v", err)
+		}
+
+		if action != diff.Unchanged {
+			t.Errorf("actual %v\nwant %v", action, diff.Unchanged)
+		}
+
+		if !reflect.DeepEqual(mockedClient.requests, []string{"GET localhost/_ingest/pipeline/test-pipeline"}) {
+			t.Errorf("unexpected requests %v", mockedClient.requests)
+		}
+	})
+
+	t.Run("Bootstrap index is not created when rollover alias exists", func(t *testing.T) {
+		mockedClient.requests = nil
+
+		action, err := elkClientWithMockedClient.CreateBootstrapIndex(resource.NewBootstrapIndex("logs", "template"))
+		if err != nil {
+			t.Fatalf("Create bootstrap index failed: %v", err)
+		}
+
+		if action != diff.Unchanged {
+			t.Errorf("actual %v\nwant %v", action, diff.Unchanged)
+		}
+
+		if !reflect.DeepEqual(mockedClient.requests, []string{"GET localhost/_alias/logs"}) {
+			t.Errorf("unexpected requests %v", mockedClient.requests)
+		}
+	})
+
+	t.Run("Bootstrap index is created when rollover alias is missing", func(t *testing.T) {
+		mockedClient.requests = nil
+
+		action, err := elkClientWithMockedClient.CreateBootstrapIndex(resource.NewBootstrapIndex("metrics", "template"))
+		if err != nil {
+			t.Fatalf("Create bootstrap index failed: %v", err)
+		}
+
+		if action != diff.Create {
+			t.Errorf("actual %v\nwant %v", action, diff.Create)
+		}
+
+		expectedRequests := []string{
+			"GET localhost/_alias/metrics",
+			"PUT localhost/metrics-000001",
+		}
+
+		if !reflect.DeepEqual(mockedClient.requests, expectedRequests) {
+			t.Errorf("actual %v\nwant %v", mockedClient.requests, expectedRequests)
+		}
+	})
+
+	t.Run("Existing data stream is not created", func(t *testing.T) {
+		mockedClient.requests = nil
+
+		action, err := elkClientWithMockedClient.CreateDataStream(&resource.DataStream{Name: "logs-app-default"})
+		if err != nil {
+			t.Fatalf("Create data stream failed: %v", err)
+		}
+
+		if action != diff.Unchanged {
+			t.Errorf("actual %v\nwant %v", action, diff.Unchanged)
+		}
+
+		if !reflect.DeepEqual(mockedClient.requests, []string{"GET localhost/_data_stream/logs-app-default"}) {
+			t.Errorf("unexpected requests %v", mockedClient.requests)
+		}
+	})
+
+	t.Run("Missing data stream is created", func(t *testing.T) {
+		mockedClient.requests = nil
+
+		action, err := elkClientWithMockedClient.CreateDataStream(&resource.DataStream{Name: "logs-app-new"})
+		if err != nil {
+			t.Fatalf("Create data stream failed: %v", err)
+		}
+
+		if action != diff.Create {
+			t.Errorf("actual %v\nwant %v", action, diff.Create)
+		}
+
+		expectedRequests := []string{
+			"GET localhost/_data_stream/logs-app-new",
+			"PUT localhost/_data_stream/logs-app-new",
+		}
+
+		if !reflect.DeepEqual(mockedClient.requests, expectedRequests) {
+			t.Errorf("actual %v\nwant %v", mockedClient.requests, expectedRequests)
+		}
+	})
+
+	t.Run("Unchanged index template is not updated", func(t *testing.T) {
+		mockedClient.requests = nil
+
+		action, err := elkClientWithMockedClient.CreateOrUpdateIndexTemplate(&resource.IndexTemplate{
+			Name:          "test-index-template",
+			Patterns:      []string{"pattern"},
+			IlmPolicyName: "test-policy",
+		})
+		if err != nil {
+			t.Fatalf("Create index template failed: %v", err)
+		}
+
+		if action != diff.Unchanged {
+			t.Errorf("actual %v\nwant %v", action, diff.Unchanged)
+		}
+
+		if len(mockedClient.requests) != 1 {
+			t.Errorf("unexpected requests %v", mockedClient.requests)
+		}
 	})
 }
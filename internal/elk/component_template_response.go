@@ -0,0 +1,19 @@
+package elk
+
+import "github.com/mihai-valentin/polyroll/internal/resource"
+
+type componentTemplateResponse struct {
+	ComponentTemplates []struct {
+		Name              string                           `json:"name"`
+		ComponentTemplate resource.ComponentTemplateSchema `json:"component_template"`
+	} `json:"component_templates"`
+}
+
+type componentTemplateListResponse struct {
+	ComponentTemplates []struct {
+		Name              string `json:"name"`
+		ComponentTemplate struct {
+			Meta map[string]any `json:"_meta"`
+		} `json:"component_template"`
+	} `json:"component_templates"`
+}
@@ -0,0 +1,7 @@
+package elk
+
+type dataStreamResponse struct {
+	DataStreams []struct {
+		Name string `json:"name"`
+	} `json:"data_streams"`
+}
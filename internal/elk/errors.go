@@ -0,0 +1,25 @@
+package elk
+
+import "fmt"
+
+type ConnectionError struct {
+	Err error
+}
+
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("cannot connect to ELK: %s", e.Err)
+}
+
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+type ResponseError struct {
+	StatusCode int
+	Type       string
+	Reason     string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("ELK API call failed with status code %d: %s", e.StatusCode, e.Reason)
+}
@@ -0,0 +1,89 @@
+package elk
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	HostSelectionRoundRobin = "roundRobin"
+	HostSelectionSticky     = "sticky"
+)
+
+const DefaultDeadHostCooldown = 30 * time.Second
+
+type host struct {
+	url       string
+	deadUntil time.Time
+}
+
+type hostPool struct {
+	mu        sync.Mutex
+	hosts     []*host
+	selection string
+	cooldown  time.Duration
+	current   int
+	now       func() time.Time
+}
+
+func newHostPool(urls []string, selection string, cooldown time.Duration) *hostPool {
+	pool := &hostPool{
+		selection: selection,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+
+	for _, url := range urls {
+		pool.hosts = append(pool.hosts, &host{url: url})
+	}
+
+	return pool
+}
+
+func (p *hostPool) size() int {
+	return len(p.hosts)
+}
+
+func (p *hostPool) next() *host {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+
+	for i := range p.hosts {
+		index := (p.current + i) % len(p.hosts)
+		if p.hosts[index].deadUntil.After(now) {
+			continue
+		}
+
+		p.current = index
+		if p.selection != HostSelectionSticky {
+			p.current = (index + 1) % len(p.hosts)
+		}
+
+		return p.hosts[index]
+	}
+
+	earliest := p.hosts[0]
+	for _, h := range p.hosts[1:] {
+		if h.deadUntil.Before(earliest.deadUntil) {
+			earliest = h
+		}
+	}
+
+	return earliest
+}
+
+func (p *hostPool) markDead(h *host) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	h.deadUntil = p.now().Add(p.cooldown)
+}
+
+func (p *hostPool) markAlive(h *host) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	h.deadUntil = time.Time{}
+}
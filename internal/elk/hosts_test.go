@@ -0,0 +1,149 @@
+package elk
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type HostsMockedClient struct {
+	unreachable map[string]bool
+	requests    []string
+}
+
+func (c *HostsMockedClient) Do(req *http.Request) (*http.Response, error) {
+	c.requests = append(c.requests, req.URL.String())
+
+	if c.unreachable[req.URL.Host] {
+		return nil, errors.New("connection refused")
+	}
+
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+	}, nil
+}
+
+func selectHosts(pool *hostPool, count int) []string {
+	var selected []string
+	for i := 0; i < count; i++ {
+		selected = append(selected, pool.next().url)
+	}
+
+	return selected
+}
+
+func TestHostPool(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	urls := []string{"http://es-1/", "http://es-2/", "http://es-3/"}
+
+	t.Run("Round robin selection", func(t *testing.T) {
+		pool := newHostPool(urls, HostSelectionRoundRobin, time.Minute)
+
+		expected := []string{"http://es-1/", "http://es-2/", "http://es-3/", "http://es-1/"}
+		if actual := selectHosts(pool, 4); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("actual %v\nwant %v", actual, expected)
+		}
+	})
+
+	t.Run("Sticky selection", func(t *testing.T) {
+		pool := newHostPool(urls, HostSelectionSticky, time.Minute)
+		pool.now = func() time.Time { return now }
+
+		expected := []string{"http://es-1/", "http://es-1/"}
+		if actual := selectHosts(pool, 2); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("actual %v\nwant %v", actual, expected)
+		}
+
+		pool.markDead(pool.hosts[0])
+
+		expected = []string{"http://es-2/", "http://es-2/"}
+		if actual := selectHosts(pool, 2); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("actual %v\nwant %v", actual, expected)
+		}
+	})
+
+	t.Run("Dead hosts are skipped until the cooldown expires", func(t *testing.T) {
+		pool := newHostPool(urls, HostSelectionRoundRobin, time.Minute)
+		pool.now = func() time.Time { return now }
+
+		pool.markDead(pool.hosts[1])
+
+		expected := []string{"http://es-1/", "http://es-3/", "http://es-1/"}
+		if actual := selectHosts(pool, 3); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("actual %v\nwant %v", actual, expected)
+		}
+
+		pool.now = func() time.Time { return now.Add(time.Minute + time.Second) }
+
+		expected = []string{"http://es-2/", "http://es-3/"}
+		if actual := selectHosts(pool, 2); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("actual %v\nwant %v", actual, expected)
+		}
+	})
+
+	t.Run("All hosts dead selects the host with the earliest cooldown", func(t *testing.T) {
+		pool := newHostPool(urls, HostSelectionRoundRobin, time.Minute)
+
+		current := now
+		pool.now = func() time.Time { return current }
+
+		for _, i := range []int{2, 0, 1} {
+			pool.markDead(pool.hosts[i])
+			current = current.Add(time.Second)
+		}
+
+		if actual := pool.next().url; actual != "http://es-3/" {
+			t.Errorf("actual %v\nwant %v", actual, "http://es-3/")
+		}
+	})
+}
+
+func TestElkClient_Failover(t *testing.T) {
+	t.Run("Unreachable host fails over to the next host", func(t *testing.T) {
+		mockedClient := &HostsMockedClient{unreachable: map[string]bool{"es-1": true}}
+
+		ec := &Client{
+			HttpClient: mockedClient,
+			hosts:      newHostPool([]string{"http://es-1/", "http://es-2/"}, HostSelectionRoundRobin, time.Minute),
+			auth:       Auth{Type: AuthNone},
+		}
+
+		for i := 0; i < 2; i++ {
+			if _, err := ec.ListIlmPolicies(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		expected := []string{"http://es-1/_ilm/policy", "http://es-2/_ilm/policy", "http://es-2/_ilm/policy"}
+		if !reflect.DeepEqual(mockedClient.requests, expected) {
+			t.Errorf("actual %v\nwant %v", mockedClient.requests, expected)
+		}
+	})
+
+	t.Run("All hosts unreachable", func(t *testing.T) {
+		mockedClient := &HostsMockedClient{unreachable: map[string]bool{"es-1": true, "es-2": true}}
+
+		ec := &Client{
+			HttpClient: mockedClient,
+			hosts:      newHostPool([]string{"http://es-1/", "http://es-2/"}, HostSelectionSticky, time.Minute),
+			auth:       Auth{Type: AuthNone},
+		}
+
+		_, err := ec.ListIlmPolicies()
+
+		var connectionError *ConnectionError
+		if !errors.As(err, &connectionError) {
+			t.Fatalf("expected connection error, got %v", err)
+		}
+
+		if len(mockedClient.requests) != 2 || !strings.HasPrefix(mockedClient.requests[1], "http://es-2/") {
+			t.Errorf("actual %v\nwant one request per host", mockedClient.requests)
+		}
+	})
+}
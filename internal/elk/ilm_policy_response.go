@@ -0,0 +1,12 @@
+package elk
+
+import "github.com/mihai-valentin/polyroll/internal/resource"
+
+type ilmPolicyDefinition struct {
+	Phases map[string]resource.PolicyPhase `json:"phases"`
+	Meta   map[string]any                  `json:"_meta"`
+}
+
+type ilmPolicyResponse map[string]struct {
+	Policy ilmPolicyDefinition `json:"policy"`
+}
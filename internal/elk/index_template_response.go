@@ -0,0 +1,19 @@
+package elk
+
+import "github.com/mihai-valentin/polyroll/internal/resource"
+
+type indexTemplateResponse struct {
+	IndexTemplates []struct {
+		Name          string                       `json:"name"`
+		IndexTemplate resource.IndexTemplateSchema `json:"index_template"`
+	} `json:"index_templates"`
+}
+
+type indexTemplateListResponse struct {
+	IndexTemplates []struct {
+		Name          string `json:"name"`
+		IndexTemplate struct {
+			Meta map[string]any `json:"_meta"`
+		} `json:"index_template"`
+	} `json:"index_templates"`
+}
@@ -0,0 +1,9 @@
+package elk
+
+import "github.com/mihai-valentin/polyroll/internal/resource"
+
+type ingestPipelineResponse map[string]resource.IngestPipelineSchema
+
+type ingestPipelineListResponse map[string]struct {
+	Meta map[string]any `json:"_meta"`
+}
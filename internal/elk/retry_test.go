@@ -0,0 +1,212 @@
+package elk
+
+import (
+	"bytes"
+	"errors"
+	"github.com/mihai-valentin/polyroll/internal/diff"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type SequenceMockedClient struct {
+	responses  []mockedResponse
+	errs       []error
+	bodies     []string
+	urls       []string
+	retryAfter string
+}
+
+func (c *SequenceMockedClient) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+	}
+	c.bodies = append(c.bodies, string(body))
+	c.urls = append(c.urls, req.URL.String())
+
+	attempt := len(c.bodies) - 1
+	if attempt < len(c.errs) && c.errs[attempt] != nil {
+		return nil, c.errs[attempt]
+	}
+
+	response := c.responses[attempt]
+
+	header := http.Header{}
+	if response.statusCode == http.StatusTooManyRequests {
+		header.Set("Retry-After", c.retryAfter)
+	}
+
+	return &http.Response{
+		StatusCode: response.statusCode,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewBufferString(response.body)),
+	}, nil
+}
+
+func TestRetry_Backoff(t *testing.T) {
+	retry := Retry{MaxRetries: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, limit := range []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	} {
+		delay := retry.backoff(attempt)
+
+		if delay < limit/2 || delay > limit {
+			t.Errorf("attempt %d: actual %v\nwant between %v and %v", attempt, delay, limit/2, limit)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for value, expected := range map[string]time.Duration{
+		"3":                             3 * time.Second,
+		"Sun, 01 Jan 2023 00:00:05 GMT": 5 * time.Second,
+		"Sat, 31 Dec 2022 23:59:00 GMT": 0,
+	} {
+		resp := &http.Response{Header: http.Header{"Retry-After": []string{value}}}
+
+		actual, ok := parseRetryAfter(resp, now)
+		if !ok || actual != expected {
+			t.Errorf("%s: actual %v\nwant %v", value, actual, expected)
+		}
+	}
+
+	if _, ok := parseRetryAfter(&http.Response{Header: http.Header{"Retry-After": []string{"soon"}}}, now); ok {
+		t.Errorf("invalid Retry-After value should be ignored")
+	}
+}
+
+func TestElkClient_Retry(t *testing.T) {
+	newClient := func(httpClient HttpClient, maxRetries int, delays *[]time.Duration) *Client {
+		return &Client{
+			HttpClient: httpClient,
+			hosts:      newHostPool([]string{"localhost/"}, HostSelectionRoundRobin, 0),
+			auth:       Auth{Type: AuthBasic, Token: "token"},
+			retry:      Retry{MaxRetries: maxRetries, InitialBackoff: 100 * time.Millisecond, MaxBackoff: 10 * time.Second},
+			sleep: func(delay time.Duration) {
+				*delays = append(*delays, delay)
+			},
+		}
+	}
+
+	t.Run("Retryable status codes and connection errors are retried", func(t *testing.T) {
+		var delays []time.Duration
+
+		mockedClient := &SequenceMockedClient{
+			retryAfter: "7",
+			errs:       []error{errors.New("connection refused")},
+			responses: []mockedResponse{
+				{},
+				{503, `{"error": {"type": "unavailable", "reason": "busy"}, "status": 503}`},
+				{429, `{"error": {"type": "es_rejected_execution_exception", "reason": "busy"}, "status": 429}`},
+				{200, `{"acknowledged": true}`},
+			},
+		}
+
+		ec := newClient(mockedClient, 3, &delays)
+
+		action, err := ec.putResource(ec.endpoint(ilmPolicyEndpoint, "test-policy"), map[string]any{"policy": "body"}, &diff.Result{Action: diff.Create})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if action != diff.Create {
+			t.Errorf("actual %v\nwant %v", action, diff.Create)
+		}
+
+		expectedBodies := []string{`{"policy":"body"}`, `{"policy":"body"}`, `{"policy":"body"}`, `{"policy":"body"}`}
+		if !reflect.DeepEqual(mockedClient.bodies, expectedBodies) {
+			t.Errorf("actual %v\nwant %v", mockedClient.bodies, expectedBodies)
+		}
+
+		expectedURLs := []string{
+			"localhost/_ilm/policy/test-policy",
+			"localhost/_ilm/policy/test-policy",
+			"localhost/_ilm/policy/test-policy",
+			"localhost/_ilm/policy/test-policy",
+		}
+		if !reflect.DeepEqual(mockedClient.urls, expectedURLs) {
+			t.Errorf("actual %v\nwant %v", mockedClient.urls, expectedURLs)
+		}
+
+		if len(delays) != 3 || delays[2] != 7*time.Second {
+			t.Errorf("actual %v\nwant 3 delays ending with Retry-After 7s", delays)
+		}
+	})
+
+	t.Run("Retry-After is limited by the maximum backoff", func(t *testing.T) {
+		var delays []time.Duration
+
+		mockedClient := &SequenceMockedClient{
+			retryAfter: "86400",
+			responses: []mockedResponse{
+				{429, `{"error": {"type": "es_rejected_execution_exception", "reason": "busy"}, "status": 429}`},
+				{200, `{}`},
+			},
+		}
+
+		ec := newClient(mockedClient, 1, &delays)
+
+		if _, err := ec.ListIlmPolicies(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(delays, []time.Duration{10 * time.Second}) {
+			t.Errorf("actual %v\nwant %v", delays, []time.Duration{10 * time.Second})
+		}
+	})
+
+	t.Run("Retries are exhausted", func(t *testing.T) {
+		var delays []time.Duration
+
+		mockedClient := &SequenceMockedClient{
+			responses: []mockedResponse{
+				{502, `{"error": {"type": "bad_gateway", "reason": "bad gateway"}, "status": 502}`},
+				{504, `{"error": {"type": "gateway_timeout", "reason": "gateway timeout"}, "status": 504}`},
+			},
+		}
+
+		ec := newClient(mockedClient, 1, &delays)
+
+		_, err := ec.ListIlmPolicies()
+
+		var responseError *ResponseError
+		if !errors.As(err, &responseError) || responseError.StatusCode != 504 {
+			t.Fatalf("expected response error with status code 504, got %v", err)
+		}
+
+		if len(delays) != 1 {
+			t.Errorf("actual %v\nwant %v", len(delays), 1)
+		}
+	})
+
+	t.Run("Client errors are not retried", func(t *testing.T) {
+		var delays []time.Duration
+
+		mockedClient := &SequenceMockedClient{
+			responses: []mockedResponse{
+				{400, `{"error": {"type": "illegal_argument_exception", "reason": "bad request"}, "status": 400}`},
+			},
+		}
+
+		ec := newClient(mockedClient, 3, &delays)
+
+		if _, err := ec.ListIlmPolicies(); err == nil {
+			t.Fatal("expected bad request error")
+		}
+
+		if len(mockedClient.bodies) != 1 || len(delays) != 0 {
+			t.Errorf("actual %d requests, %d delays\nwant 1 request, 0 delays", len(mockedClient.bodies), len(delays))
+		}
+	})
+}
@@ -0,0 +1,13 @@
+package elk
+
+import "github.com/mihai-valentin/polyroll/internal/resource"
+
+type slmPolicyResponse map[string]struct {
+	Policy resource.SlmPolicySchema `json:"policy"`
+}
+
+type slmPolicyListResponse map[string]struct {
+	Policy struct {
+		Meta map[string]any `json:"_meta"`
+	} `json:"policy"`
+}
@@ -0,0 +1,5 @@
+package elk
+
+import "github.com/mihai-valentin/polyroll/internal/resource"
+
+type snapshotRepositoryResponse map[string]resource.SnapshotRepositorySchema
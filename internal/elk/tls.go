@@ -0,0 +1,99 @@
+package elk
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type TLS struct {
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	CAFingerprint      string
+	InsecureSkipVerify bool
+}
+
+func (t TLS) config() (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read CA file [%s]: %w", t.CAFile, err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA file [%s] has no valid PEM certificates", t.CAFile)
+		}
+
+		config.RootCAs = pool
+	}
+
+	if t.CertFile != "" || t.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load client certificate [%s] and key [%s]: %w", t.CertFile, t.KeyFile, err)
+		}
+
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	if t.CAFingerprint != "" {
+		fingerprint := NormalizeFingerprint(t.CAFingerprint)
+
+		config.InsecureSkipVerify = true
+		config.VerifyConnection = func(state tls.ConnectionState) error {
+			return verifyPinnedChain(state.PeerCertificates, state.ServerName, fingerprint)
+		}
+	}
+
+	return config, nil
+}
+
+func verifyPinnedChain(certificates []*x509.Certificate, serverName string, fingerprint string) error {
+	if len(certificates) == 0 {
+		return errors.New("server presented no certificates")
+	}
+
+	roots := x509.NewCertPool()
+	intermediates := x509.NewCertPool()
+	pinned := false
+
+	for i, certificate := range certificates {
+		sum := sha256.Sum256(certificate.Raw)
+		if hex.EncodeToString(sum[:]) == fingerprint {
+			roots.AddCert(certificate)
+			pinned = true
+		} else if i > 0 {
+			intermediates.AddCert(certificate)
+		}
+	}
+
+	if !pinned {
+		return errors.New("no certificate in the server chain matches the CA fingerprint")
+	}
+
+	_, err := certificates[0].Verify(x509.VerifyOptions{
+		DNSName:       serverName,
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	if err != nil {
+		return fmt.Errorf("server certificate is not signed by the CA matching the fingerprint: %w", err)
+	}
+
+	return nil
+}
+
+func NormalizeFingerprint(fingerprint string) string {
+	return strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))
+}
@@ -0,0 +1,231 @@
+package elk
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTLSTestServer(t *testing.T, config *tls.Config) *httptest.Server {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"index_templates": []}`))
+	}))
+
+	if config != nil {
+		server.TLS = config
+	}
+
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func writePEMFile(t *testing.T, name string, blockType string, der []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func generateCertificate(t *testing.T, template *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return certificate, key
+}
+
+func caTemplate(name string) *x509.Certificate {
+	return &x509.Certificate{
+		Subject:               pkix.Name{CommonName: name},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+}
+
+func serverTemplate() *x509.Certificate {
+	return &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "elasticsearch"},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+}
+
+func generateClientCertificate(t *testing.T) (*x509.Certificate, string, string) {
+	template := caTemplate("polyroll")
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+
+	certificate, key := generateCertificate(t, template, nil, nil)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return certificate, writePEMFile(t, "client.crt", "CERTIFICATE", certificate.Raw), writePEMFile(t, "client.key", "EC PRIVATE KEY", keyDer)
+}
+
+func fingerprintOf(certificate *x509.Certificate) string {
+	sum := sha256.Sum256(certificate.Raw)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func listIndexTemplates(t *testing.T, server *httptest.Server, options TLS) error {
+	ec, err := NewElkClient([]string{server.URL + "/"}, Options{Auth: Auth{Type: AuthNone}, TLS: options})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ec.ListIndexTemplates()
+
+	return err
+}
+
+func TestElkClient_TLS(t *testing.T) {
+	server := newTLSTestServer(t, nil)
+	caFile := writePEMFile(t, "ca.crt", "CERTIFICATE", server.Certificate().Raw)
+
+	sum := sha256.Sum256(server.Certificate().Raw)
+	var fingerprint []string
+	for _, b := range sum {
+		fingerprint = append(fingerprint, fmt.Sprintf("%02X", b))
+	}
+
+	t.Run("Unknown certificate authority is rejected", func(t *testing.T) {
+		if err := listIndexTemplates(t, server, TLS{}); err == nil {
+			t.Fatal("expected certificate verification error")
+		}
+	})
+
+	t.Run("Custom CA file is trusted", func(t *testing.T) {
+		if err := listIndexTemplates(t, server, TLS{CAFile: caFile}); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("Matching CA fingerprint is trusted", func(t *testing.T) {
+		if err := listIndexTemplates(t, server, TLS{CAFingerprint: strings.Join(fingerprint, ":")}); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("Mismatching CA fingerprint is rejected", func(t *testing.T) {
+		wrongFingerprint := strings.Repeat("00", sha256.Size)
+
+		if err := listIndexTemplates(t, server, TLS{CAFingerprint: wrongFingerprint}); err == nil {
+			t.Fatal("expected fingerprint mismatch error")
+		}
+	})
+
+	t.Run("Certificate verification can be skipped", func(t *testing.T) {
+		if err := listIndexTemplates(t, server, TLS{InsecureSkipVerify: true}); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("Missing CA file fails", func(t *testing.T) {
+		_, err := NewElkClient([]string{server.URL + "/"}, Options{TLS: TLS{CAFile: filepath.Join(t.TempDir(), "missing.crt")}})
+		if err == nil {
+			t.Fatal("expected missing CA file error")
+		}
+	})
+}
+
+func TestElkClient_MutualTLS(t *testing.T) {
+	clientCertificate, certFile, keyFile := generateClientCertificate(t)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCertificate)
+
+	server := newTLSTestServer(t, &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	})
+	caFile := writePEMFile(t, "ca.crt", "CERTIFICATE", server.Certificate().Raw)
+
+	t.Run("Missing client certificate is rejected", func(t *testing.T) {
+		if err := listIndexTemplates(t, server, TLS{CAFile: caFile}); err == nil {
+			t.Fatal("expected client certificate error")
+		}
+	})
+
+	t.Run("Client certificate is sent", func(t *testing.T) {
+		if err := listIndexTemplates(t, server, TLS{CAFile: caFile, CertFile: certFile, KeyFile: keyFile}); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	})
+}
+
+func TestElkClient_TLSFingerprintChain(t *testing.T) {
+	ca, caKey := generateCertificate(t, caTemplate("elasticsearch-ca"), nil, nil)
+
+	newChainServer := func(leaf *x509.Certificate, key *ecdsa.PrivateKey) *httptest.Server {
+		return newTLSTestServer(t, &tls.Config{
+			Certificates: []tls.Certificate{{
+				Certificate: [][]byte{leaf.Raw, ca.Raw},
+				PrivateKey:  key,
+			}},
+		})
+	}
+
+	t.Run("Leaf signed by the pinned CA is trusted", func(t *testing.T) {
+		leaf, leafKey := generateCertificate(t, serverTemplate(), ca, caKey)
+		server := newChainServer(leaf, leafKey)
+
+		if err := listIndexTemplates(t, server, TLS{CAFingerprint: fingerprintOf(ca)}); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("Leaf not signed by the pinned CA is rejected", func(t *testing.T) {
+		attackerCA, attackerKey := generateCertificate(t, caTemplate("attacker-ca"), nil, nil)
+		leaf, leafKey := generateCertificate(t, serverTemplate(), attackerCA, attackerKey)
+		server := newChainServer(leaf, leafKey)
+
+		if err := listIndexTemplates(t, server, TLS{CAFingerprint: fingerprintOf(ca)}); err == nil {
+			t.Fatal("expected certificate verification error")
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Node struct {
+	Kind         string
+	Name         string
+	Resource     any
+	Dependencies []*Node
+}
+
+type Graph struct {
+	kindRank map[string]int
+	nodes    map[string]*Node
+}
+
+func New(kindOrder []string) *Graph {
+	g := &Graph{
+		kindRank: map[string]int{},
+		nodes:    map[string]*Node{},
+	}
+
+	for rank, kind := range kindOrder {
+		g.kindRank[kind] = rank
+	}
+
+	return g
+}
+
+func (g *Graph) Add(kind string, name string, resource any) *Node {
+	node := &Node{Kind: kind, Name: name, Resource: resource}
+	g.nodes[key(kind, name)] = node
+
+	return node
+}
+
+func (g *Graph) Get(kind string, name string) *Node {
+	return g.nodes[key(kind, name)]
+}
+
+func (g *Graph) AddDependency(node *Node, kind string, name string) bool {
+	dependency := g.Get(kind, name)
+	if dependency == nil {
+		return false
+	}
+
+	for _, existing := range node.Dependencies {
+		if existing == dependency {
+			return true
+		}
+	}
+
+	node.Dependencies = append(node.Dependencies, dependency)
+
+	return true
+}
+
+func (g *Graph) Sort() ([]*Node, error) {
+	pending := map[*Node]int{}
+	dependents := map[*Node][]*Node{}
+
+	for _, node := range g.nodes {
+		pending[node] = len(node.Dependencies)
+
+		for _, dependency := range node.Dependencies {
+			dependents[dependency] = append(dependents[dependency], node)
+		}
+	}
+
+	var ready []*Node
+	for node, count := range pending {
+		if count == 0 {
+			ready = append(ready, node)
+		}
+	}
+
+	sorted := make([]*Node, 0, len(g.nodes))
+
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool {
+			return g.less(ready[i], ready[j])
+		})
+
+		node := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, node)
+		delete(pending, node)
+
+		for _, dependent := range dependents[node] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(pending) > 0 {
+		var cycle []string
+		for node := range pending {
+			cycle = append(cycle, fmt.Sprintf("%s [%s]", node.Kind, node.Name))
+		}
+
+		sort.Strings(cycle)
+
+		return nil, fmt.Errorf("dependency cycle detected between %s", strings.Join(cycle, ", "))
+	}
+
+	return sorted, nil
+}
+
+func (g *Graph) less(a *Node, b *Node) bool {
+	if g.kindRank[a.Kind] != g.kindRank[b.Kind] {
+		return g.kindRank[a.Kind] < g.kindRank[b.Kind]
+	}
+
+	if a.Kind != b.Kind {
+		return a.Kind < b.Kind
+	}
+
+	return a.Name < b.Name
+}
+
+func key(kind string, name string) string {
+	return kind + "/" + name
+}
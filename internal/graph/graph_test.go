@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func names(nodes []*Node) []string {
+	var result []string
+	for _, node := range nodes {
+		result = append(result, node.Kind+"/"+node.Name)
+	}
+
+	return result
+}
+
+func TestGraph_Sort(t *testing.T) {
+	t.Run("Nodes are ordered by kind and name", func(t *testing.T) {
+		g := New([]string{"policy", "template"})
+		g.Add("template", "b", nil)
+		g.Add("template", "a", nil)
+		g.Add("policy", "z", nil)
+		g.Add("policy", "y", nil)
+
+		expected := []string{"policy/y", "policy/z", "template/a", "template/b"}
+
+		for i := 0; i < 10; i++ {
+			actual, err := g.Sort()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(names(actual), expected) {
+				t.Fatalf("actual %v\nwant %v", names(actual), expected)
+			}
+		}
+	})
+
+	t.Run("Dependencies are ordered before dependents of the same kind", func(t *testing.T) {
+		g := New([]string{"pipeline"})
+		a := g.Add("pipeline", "a", nil)
+		g.Add("pipeline", "b", nil)
+		g.Add("pipeline", "c", nil)
+
+		if !g.AddDependency(a, "pipeline", "c") {
+			t.Fatal("expected dependency to be added")
+		}
+
+		expected := []string{"pipeline/b", "pipeline/c", "pipeline/a"}
+
+		actual, err := g.Sort()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(names(actual), expected) {
+			t.Errorf("actual %v\nwant %v", names(actual), expected)
+		}
+	})
+
+	t.Run("Undefined dependency is not added", func(t *testing.T) {
+		g := New([]string{"policy", "template"})
+		template := g.Add("template", "a", nil)
+
+		if g.AddDependency(template, "policy", "missing") {
+			t.Error("expected undefined dependency to be ignored")
+		}
+
+		if len(template.Dependencies) != 0 {
+			t.Errorf("unexpected dependencies %v", template.Dependencies)
+		}
+	})
+
+	t.Run("Dependency cycle is detected", func(t *testing.T) {
+		g := New([]string{"pipeline"})
+		a := g.Add("pipeline", "a", nil)
+		b := g.Add("pipeline", "b", nil)
+		g.Add("pipeline", "c", nil)
+		g.AddDependency(a, "pipeline", "b")
+		g.AddDependency(b, "pipeline", "a")
+
+		_, err := g.Sort()
+		if err == nil {
+			t.Fatal("expected dependency cycle error")
+		}
+
+		expected := "dependency cycle detected between pipeline [a], pipeline [b]"
+		if err.Error() != expected {
+			t.Errorf("actual %v\nwant %v", err, expected)
+		}
+	})
+}
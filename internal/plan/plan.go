@@ -0,0 +1,183 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/mihai-valentin/polyroll/internal"
+	"github.com/mihai-valentin/polyroll/internal/diff"
+	"github.com/mihai-valentin/polyroll/internal/elk"
+	"github.com/mihai-valentin/polyroll/internal/resource"
+	"io"
+)
+
+type ResourceDiff struct {
+	Kind string
+	Name string
+	*diff.Result
+}
+
+type Plan struct {
+	Resources []ResourceDiff
+}
+
+func Build(ec *elk.Client, config *internal.Config) (*Plan, error) {
+	p := &Plan{}
+
+	resources, err := config.Resources()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range resources {
+		result, err := ec.PlanResource(node.Resource)
+		if err != nil {
+			return nil, fmt.Errorf("cannot plan %s [%s]: %w", node.Kind, node.Name, err)
+		}
+
+		p.Resources = append(p.Resources, ResourceDiff{node.Kind, node.Name, result})
+	}
+
+	if err := p.addOrphans(resource.SnapshotRepositoryKind, ec.ListSnapshotRepositories); err != nil {
+		return nil, err
+	}
+
+	if err := p.addOrphans(resource.SlmPolicyKind, ec.ListSlmPolicies); err != nil {
+		return nil, err
+	}
+
+	if err := p.addOrphans(resource.IngestPipelineKind, ec.ListIngestPipelines); err != nil {
+		return nil, err
+	}
+
+	if err := p.addOrphans(resource.IlmPolicyKind, ec.ListIlmPolicies); err != nil {
+		return nil, err
+	}
+
+	if err := p.addOrphans(resource.ComponentTemplateKind, ec.ListComponentTemplates); err != nil {
+		return nil, err
+	}
+
+	if err := p.addOrphans(resource.IndexTemplateKind, ec.ListIndexTemplates); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *Plan) addOrphans(kind string, list func() ([]string, error)) error {
+	defined := map[string]bool{}
+	for _, r := range p.Resources {
+		if r.Kind == kind {
+			defined[r.Name] = true
+		}
+	}
+
+	names, err := list()
+	if err != nil {
+		return fmt.Errorf("cannot list %s resources: %w", kind, err)
+	}
+
+	for _, name := range names {
+		if !defined[name] {
+			p.Resources = append(p.Resources, ResourceDiff{kind, name, &diff.Result{Action: diff.Orphan}})
+		}
+	}
+
+	return nil
+}
+
+func (p *Plan) HasPendingChanges() bool {
+	for _, r := range p.Resources {
+		if r.Action == diff.Create || r.Action == diff.Update {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Plan) Print(w io.Writer) {
+	summary := map[diff.Action]int{}
+
+	for _, r := range p.Resources {
+		summary[r.Action]++
+
+		switch r.Action {
+		case diff.Create:
+			fmt.Fprintf(w, "+ %s [%s] will be created\n", r.Kind, r.Name)
+		case diff.Update:
+			fmt.Fprintf(w, "~ %s [%s] will be updated\n", r.Kind, r.Name)
+		case diff.Unchanged:
+			fmt.Fprintf(w, "  %s [%s] is unchanged\n", r.Kind, r.Name)
+		case diff.Orphan:
+			fmt.Fprintf(w, "- %s [%s] exists in the cluster but is not defined in the config\n", r.Kind, r.Name)
+		}
+
+		for _, change := range r.Changes {
+			fmt.Fprintf(w, "    %s: %s => %s\n", change.Path, formatValue(change.Current), formatValue(change.Desired))
+		}
+	}
+
+	fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d unchanged, %d orphan.\n",
+		summary[diff.Create],
+		summary[diff.Update],
+		summary[diff.Unchanged],
+		summary[diff.Orphan],
+	)
+}
+
+type jsonChange struct {
+	Path    string `json:"path"`
+	Current any    `json:"current"`
+	Desired any    `json:"desired"`
+}
+
+type jsonResource struct {
+	Kind    string       `json:"kind"`
+	Name    string       `json:"name"`
+	Action  diff.Action  `json:"action"`
+	Changes []jsonChange `json:"changes"`
+}
+
+type jsonPlan struct {
+	Event          string         `json:"event"`
+	PendingChanges bool           `json:"pending_changes"`
+	Summary        map[string]int `json:"summary"`
+	Resources      []jsonResource `json:"resources"`
+}
+
+func (p *Plan) PrintJSON(w io.Writer) error {
+	report := jsonPlan{
+		Event:          "plan",
+		PendingChanges: p.HasPendingChanges(),
+		Summary: map[string]int{
+			string(diff.Create):    0,
+			string(diff.Update):    0,
+			string(diff.Unchanged): 0,
+			string(diff.Orphan):    0,
+		},
+		Resources: []jsonResource{},
+	}
+
+	for _, r := range p.Resources {
+		report.Summary[string(r.Action)]++
+
+		changes := []jsonChange{}
+		for _, change := range r.Changes {
+			changes = append(changes, jsonChange{change.Path, change.Current, change.Desired})
+		}
+
+		report.Resources = append(report.Resources, jsonResource{r.Kind, r.Name, r.Action, changes})
+	}
+
+	return json.NewEncoder(w).Encode(report)
+}
+
+func formatValue(v any) string {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(encoded)
+}
@@ -0,0 +1,149 @@
+package plan
+
+import (
+	"bytes"
+	"github.com/mihai-valentin/polyroll/internal"
+	"github.com/mihai-valentin/polyroll/internal/diff"
+	"github.com/mihai-valentin/polyroll/internal/elk"
+	"github.com/mihai-valentin/polyroll/internal/resource"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type MockedClient struct {
+	responses map[string]string
+}
+
+func (c *MockedClient) Do(req *http.Request) (*http.Response, error) {
+	body, ok := c.responses[req.URL.Path]
+	if !ok {
+		return &http.Response{
+			StatusCode: 404,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"error": {"reason": "not found"}, "status": 404}`)),
+		}, nil
+	}
+
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}, nil
+}
+
+func TestBuild(t *testing.T) {
+	ec, err := elk.NewElkClient([]string{"http://localhost/"}, elk.Options{Auth: elk.Auth{Type: elk.AuthBasic, Token: "token"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ec.HttpClient = &MockedClient{
+		responses: map[string]string{
+			"/_ilm/policy": `{
+                "unchanged-policy": {"policy": {"phases": {}}},
+                "updated-policy": {"policy": {"phases": {}}},
+                "orphan-policy": {"policy": {"phases": {}}}
+            }`,
+			"/_ilm/policy/unchanged-policy": `{
+                "unchanged-policy": {"policy": {"phases": {
+                    "hot": {"min_age": "0ms", "actions": {"set_priority": {"priority": 100}}}
+                }}}
+            }`,
+			"/_ilm/policy/updated-policy": `{
+                "updated-policy": {"policy": {"phases": {
+                    "hot": {"min_age": "0ms", "actions": {"set_priority": {"priority": 100}}},
+                    "warm": {"min_age": "1d", "actions": {"set_priority": {"priority": 50}}}
+                }}}
+            }`,
+			"/_index_template": `{"index_templates": []}`,
+		},
+	}
+
+	config := &internal.Config{
+		IlmPolicies: []*resource.IlmPolicy{
+			{Name: "unchanged-policy"},
+			{Name: "updated-policy", Warm: &resource.IlmPolicyPhase{MinAge: "2d"}},
+		},
+		IndexTemplates: []*resource.IndexTemplate{
+			{Name: "new-template", Patterns: []string{"foo-*"}, IlmPolicyName: "updated-policy"},
+		},
+	}
+
+	p, err := Build(ec, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedActions := map[string]diff.Action{
+		"unchanged-policy": diff.Unchanged,
+		"updated-policy":   diff.Update,
+		"new-template":     diff.Create,
+		"orphan-policy":    diff.Orphan,
+	}
+
+	if len(p.Resources) != len(expectedActions) {
+		t.Fatalf("expected %d resources, got %d", len(expectedActions), len(p.Resources))
+	}
+
+	for _, r := range p.Resources {
+		if r.Action != expectedActions[r.Name] {
+			t.Errorf("resource [%s]: actual %v\nwant %v", r.Name, r.Action, expectedActions[r.Name])
+		}
+	}
+
+	if !p.HasPendingChanges() {
+		t.Errorf("plan should have pending changes")
+	}
+
+	var output bytes.Buffer
+	p.Print(&output)
+
+	if !strings.Contains(output.String(), `policy.phases.warm.min_age: "1d" => "2d"`) {
+		t.Errorf("output should contain field level diff, got:\n%s", output.String())
+	}
+
+	if !strings.Contains(output.String(), "Plan: 1 to create, 1 to update, 1 unchanged, 1 orphan.") {
+		t.Errorf("output should contain summary, got:\n%s", output.String())
+	}
+}
+
+func TestPlan_HasPendingChanges(t *testing.T) {
+	p := &Plan{
+		Resources: []ResourceDiff{
+			{Kind: resource.IlmPolicyKind, Name: "foo", Result: &diff.Result{Action: diff.Unchanged}},
+			{Kind: resource.IndexTemplateKind, Name: "bar", Result: &diff.Result{Action: diff.Orphan}},
+		},
+	}
+
+	if p.HasPendingChanges() {
+		t.Errorf("unchanged and orphan resources should not be pending changes")
+	}
+}
+
+func TestPlan_PrintJSON(t *testing.T) {
+	p := &Plan{
+		Resources: []ResourceDiff{
+			{Kind: resource.IlmPolicyKind, Name: "foo", Result: &diff.Result{
+				Action:  diff.Update,
+				Changes: []diff.Change{{Path: "policy.phases.warm.min_age", Current: "1d", Desired: "2d"}},
+			}},
+			{Kind: resource.IndexTemplateKind, Name: "bar", Result: &diff.Result{Action: diff.Orphan}},
+		},
+	}
+
+	var output bytes.Buffer
+	if err := p.PrintJSON(&output); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"event":"plan","pending_changes":true,` +
+		`"summary":{"create":0,"orphan":1,"unchanged":0,"update":1},` +
+		`"resources":[` +
+		`{"kind":"ILM policy","name":"foo","action":"update","changes":[{"path":"policy.phases.warm.min_age","current":"1d","desired":"2d"}]},` +
+		`{"kind":"index template","name":"bar","action":"orphan","changes":[]}` +
+		`]}` + "\n"
+
+	if output.String() != expected {
+		t.Errorf("actual %s\nwant %s", output.String(), expected)
+	}
+}
@@ -0,0 +1,35 @@
+package resource
+
+import "fmt"
+
+const BootstrapIndexKind = "bootstrap index"
+
+const bootstrapIndexSuffix = "000001"
+
+type BootstrapIndex struct {
+	Name          string `yaml:"name"`
+	Alias         string `yaml:"alias"`
+	IndexTemplate string `yaml:"template"`
+}
+
+type BootstrapIndexSchema struct {
+	Aliases map[string]map[string]any `json:"aliases"`
+}
+
+func NewBootstrapIndex(alias string, indexTemplate string) *BootstrapIndex {
+	return &BootstrapIndex{
+		Name:          fmt.Sprintf("%s-%s", alias, bootstrapIndexSuffix),
+		Alias:         alias,
+		IndexTemplate: indexTemplate,
+	}
+}
+
+func (i *BootstrapIndex) Schema() BootstrapIndexSchema {
+	return BootstrapIndexSchema{
+		Aliases: map[string]map[string]any{
+			i.Alias: {
+				"is_write_index": true,
+			},
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package resource
+
+const ComponentTemplateKind = "component template"
+
+type ComponentTemplate struct {
+	Name     string         `yaml:"name"`
+	Settings Settings       `yaml:"settings"`
+	Mappings map[string]any `yaml:"mappings"`
+	Aliases  map[string]any `yaml:"aliases"`
+}
+
+type TemplateSchema struct {
+	Settings Settings       `json:"settings,omitempty"`
+	Mappings map[string]any `json:"mappings,omitempty"`
+	Aliases  map[string]any `json:"aliases,omitempty"`
+}
+
+type ComponentTemplateSchema struct {
+	Template TemplateSchema `json:"template"`
+}
+
+func (t *ComponentTemplate) Schema() ComponentTemplateSchema {
+	return ComponentTemplateSchema{
+		Template: TemplateSchema{
+			Settings: t.Settings,
+			Mappings: t.Mappings,
+			Aliases:  t.Aliases,
+		},
+	}
+}
+
+func (s *ComponentTemplateSchema) Normalize() *ComponentTemplateSchema {
+	if s == nil {
+		return nil
+	}
+
+	return &ComponentTemplateSchema{
+		Template: s.Template.Normalize(),
+	}
+}
+
+func (s TemplateSchema) Normalize() TemplateSchema {
+	return TemplateSchema{
+		Settings: s.Settings.Normalize(),
+		Mappings: s.Mappings,
+		Aliases:  s.Aliases,
+	}
+}
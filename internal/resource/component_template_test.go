@@ -0,0 +1,77 @@
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComponentTemplate_Schema(t *testing.T) {
+	t.Run("Component template default schema", func(t *testing.T) {
+		expected := ComponentTemplateSchema{}
+		actual := (&ComponentTemplate{}).Schema()
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("expected: %v, actual: %v", expected, actual)
+		}
+	})
+
+	t.Run("Component template with settings, mappings and aliases", func(t *testing.T) {
+		expected := ComponentTemplateSchema{
+			Template: TemplateSchema{
+				Settings: Settings{"number_of_shards": 1},
+				Mappings: map[string]any{
+					"properties": map[string]any{
+						"@timestamp": map[string]any{"type": "date"},
+					},
+				},
+				Aliases: map[string]any{"logs": map[string]any{}},
+			},
+		}
+
+		componentTemplate := &ComponentTemplate{
+			Settings: Settings{"number_of_shards": 1},
+			Mappings: map[string]any{
+				"properties": map[string]any{
+					"@timestamp": map[string]any{"type": "date"},
+				},
+			},
+			Aliases: map[string]any{"logs": map[string]any{}},
+		}
+
+		actual := componentTemplate.Schema()
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("expected: %v, actual: %v", expected, actual)
+		}
+	})
+}
+
+func TestComponentTemplateSchema_Normalize(t *testing.T) {
+	t.Run("Nil schema stays nil", func(t *testing.T) {
+		if actual := (*ComponentTemplateSchema)(nil).Normalize(); actual != nil {
+			t.Errorf("actual %v\nwant nil", actual)
+		}
+	})
+
+	t.Run("Settings are normalized", func(t *testing.T) {
+		expected := &ComponentTemplateSchema{
+			Template: TemplateSchema{
+				Settings: Settings{
+					"index": map[string]any{"number_of_shards": "1"},
+				},
+			},
+		}
+
+		schema := &ComponentTemplateSchema{
+			Template: TemplateSchema{
+				Settings: Settings{"number_of_shards": 1},
+			},
+		}
+
+		actual := schema.Normalize()
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("expected: %v, actual: %v", expected, actual)
+		}
+	})
+}
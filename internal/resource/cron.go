@@ -0,0 +1,156 @@
+package resource
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names []string
+}
+
+var cronFields = []cronField{
+	{name: "seconds", min: 0, max: 59},
+	{name: "minutes", min: 0, max: 59},
+	{name: "hours", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 1, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+	{name: "year", min: 1970, max: 2099},
+}
+
+const (
+	cronDayOfMonth = 3
+	cronDayOfWeek  = 5
+)
+
+func ValidateCronExpression(expression string) error {
+	fields := strings.Fields(expression)
+	if len(fields) != 6 && len(fields) != 7 {
+		return fmt.Errorf("cron expression [%s] must have 6 or 7 fields, got %d", expression, len(fields))
+	}
+
+	for i, value := range fields {
+		if err := cronFields[i].validate(i, value); err != nil {
+			return fmt.Errorf("invalid cron expression [%s]: %w", expression, err)
+		}
+	}
+
+	if (fields[cronDayOfMonth] == "?") == (fields[cronDayOfWeek] == "?") {
+		return fmt.Errorf("invalid cron expression [%s]: exactly one of day of month and day of week must be [?]", expression)
+	}
+
+	return nil
+}
+
+func (f cronField) validate(position int, value string) error {
+	if value == "?" {
+		if position != cronDayOfMonth && position != cronDayOfWeek {
+			return fmt.Errorf("[?] is only allowed in day of month and day of week fields, got it in %s", f.name)
+		}
+
+		return nil
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		if err := f.validateItem(position, item); err != nil {
+			return fmt.Errorf("%s field [%s]: %w", f.name, value, err)
+		}
+	}
+
+	return nil
+}
+
+func (f cronField) validateItem(position int, item string) error {
+	if position == cronDayOfMonth && (item == "L" || item == "LW") {
+		return nil
+	}
+
+	if position == cronDayOfMonth && strings.HasPrefix(item, "L-") {
+		_, err := f.parseValue(strings.TrimPrefix(item, "L-"))
+		return err
+	}
+
+	if position == cronDayOfMonth && strings.HasSuffix(item, "W") {
+		_, err := f.parseValue(strings.TrimSuffix(item, "W"))
+		return err
+	}
+
+	if position == cronDayOfWeek && item == "L" {
+		return nil
+	}
+
+	if position == cronDayOfWeek && strings.HasSuffix(item, "L") {
+		_, err := f.parseValue(strings.TrimSuffix(item, "L"))
+		return err
+	}
+
+	if position == cronDayOfWeek && strings.Contains(item, "#") {
+		day, nth, _ := strings.Cut(item, "#")
+		if _, err := f.parseValue(day); err != nil {
+			return err
+		}
+
+		if n, err := strconv.Atoi(nth); err != nil || n < 1 || n > 5 {
+			return fmt.Errorf("invalid nth day of week [%s], expected 1-5", nth)
+		}
+
+		return nil
+	}
+
+	base, step, hasStep := strings.Cut(item, "/")
+	if hasStep {
+		if n, err := strconv.Atoi(step); err != nil || n < 1 {
+			return fmt.Errorf("invalid increment [%s]", step)
+		}
+	}
+
+	if base == "*" {
+		return nil
+	}
+
+	from, to, isRange := strings.Cut(base, "-")
+
+	start, err := f.parseValue(from)
+	if err != nil {
+		return err
+	}
+
+	if !isRange {
+		return nil
+	}
+
+	end, err := f.parseValue(to)
+	if err != nil {
+		return err
+	}
+
+	if end < start {
+		return fmt.Errorf("invalid range [%s]", base)
+	}
+
+	return nil
+}
+
+func (f cronField) parseValue(value string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(value, name) {
+			return f.min + i, nil
+		}
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value [%s]", value)
+	}
+
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("value [%d] is out of range %d-%d", n, f.min, f.max)
+	}
+
+	return n, nil
+}
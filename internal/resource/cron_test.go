@@ -0,0 +1,42 @@
+package resource
+
+import "testing"
+
+func TestValidateCronExpression(t *testing.T) {
+	for _, expression := range []string{
+		"0 30 1 * * ?",
+		"0 0 2 ? * SUN",
+		"0 0/15 * * * ?",
+		"0 0 12 1,15 * ?",
+		"0 0 0 L * ?",
+		"0 0 0 15W JAN-MAR ?",
+		"0 0 0 ? * MON-FRI 2030",
+		"0 0 0 ? * 6#3",
+		"0 0 0 ? * 2L",
+	} {
+		if err := ValidateCronExpression(expression); err != nil {
+			t.Errorf("[%s]: unexpected error %v", expression, err)
+		}
+	}
+
+	for _, expression := range []string{
+		"",
+		"* * * * *",
+		"0 30 1 * * * * *",
+		"60 0 0 * * ?",
+		"0 0 24 * * ?",
+		"0 0 0 32 * ?",
+		"0 0 0 ? 13 *",
+		"0 0 0 * * *",
+		"0 0 0 ? * ?",
+		"? 0 0 * * ?",
+		"0 0/0 0 * * ?",
+		"0 10-5 0 * * ?",
+		"0 0 0 ? * FOO",
+		"0 0 0 ? * 6#6",
+	} {
+		if err := ValidateCronExpression(expression); err == nil {
+			t.Errorf("[%s]: expected error", expression)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package resource
+
+const DataStreamKind = "data stream"
+
+type DataStream struct {
+	Name          string `yaml:"name"`
+	IndexTemplate string `yaml:"template"`
+}
+
+type IndexTemplateDataStream struct {
+	Hidden             bool     `yaml:"hidden"`
+	AllowCustomRouting bool     `yaml:"allow_custom_routing"`
+	Create             []string `yaml:"create"`
+}
+
+type DataStreamSchema struct {
+	Hidden             bool `json:"hidden"`
+	AllowCustomRouting bool `json:"allow_custom_routing"`
+}
+
+func (d *IndexTemplateDataStream) Schema() *DataStreamSchema {
+	if d == nil {
+		return nil
+	}
+
+	return &DataStreamSchema{
+		Hidden:             d.Hidden,
+		AllowCustomRouting: d.AllowCustomRouting,
+	}
+}
@@ -1,12 +1,29 @@
 package resource
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const IlmPolicyKind = "ILM policy"
+
+var ilmPhaseOrder = []string{"hot", "warm", "cold", "frozen", "delete"}
+
+type IlmPolicyPhase struct {
+	MinAge       TimeValue
+	Priority     *int
+	OmitPriority bool
+	Actions      IlmPolicyActions
+}
 
 type IlmPolicy struct {
-	Name   string `yaml:"name"`
-	Warm   uint   `yaml:"warm"`
-	Cold   uint   `yaml:"cold"`
-	Delete uint   `yaml:"delete"`
+	Name   string          `yaml:"name"`
+	Hot    *IlmPolicyPhase `yaml:"hot"`
+	Warm   *IlmPolicyPhase `yaml:"warm"`
+	Cold   *IlmPolicyPhase `yaml:"cold"`
+	Frozen *IlmPolicyPhase `yaml:"frozen"`
+	Delete *IlmPolicyPhase `yaml:"delete"`
 }
 
 type PolicyPhaseActions map[string]map[string]any
@@ -19,51 +36,254 @@ type PolicyPhase struct {
 type ImlPolicySchema map[string]map[string]map[string]PolicyPhase
 
 func (p *IlmPolicy) Schema() ImlPolicySchema {
+	hotMinAge := TimeValue("")
+	if p.Hot != nil {
+		hotMinAge = p.Hot.MinAge
+	}
+
 	schema := ImlPolicySchema{
 		"policy": {
 			"phases": {
-				"hot": PolicyPhase{
-					MinAge: "0ms",
-					Actions: PolicyPhaseActions{
-						"set_priority": {
-							"priority": 100,
-						},
-					},
-				},
+				"hot": p.Hot.schema(hotMinAge.String(), 100),
 			},
 		},
 	}
 
-	if p.Warm > 0 {
-		schema["policy"]["phases"]["warm"] = PolicyPhase{
-			MinAge: fmt.Sprintf("%dd", p.Warm),
-			Actions: PolicyPhaseActions{
-				"set_priority": {
-					"priority": 50,
-				},
-			},
+	if p.Warm != nil {
+		schema["policy"]["phases"]["warm"] = p.Warm.schema(p.Warm.MinAge.String(), 50)
+	}
+
+	if p.Cold != nil {
+		schema["policy"]["phases"]["cold"] = p.Cold.schema(p.Cold.MinAge.String(), 0)
+	}
+
+	if p.Frozen != nil {
+		schema["policy"]["phases"]["frozen"] = PolicyPhase{
+			MinAge:  p.Frozen.MinAge.String(),
+			Actions: p.Frozen.Actions.schema(),
 		}
 	}
 
-	if p.Warm > 0 && p.Cold > 0 {
-		schema["policy"]["phases"]["cold"] = PolicyPhase{
-			MinAge: fmt.Sprintf("%dd", p.Cold),
-			Actions: PolicyPhaseActions{
-				"set_priority": {
-					"priority": 0,
-				},
-			},
+	if p.Delete != nil {
+		schema["policy"]["phases"]["delete"] = p.Delete.deleteSchema(p.Delete.MinAge.String())
+	}
+
+	return schema
+}
+
+func (p *IlmPolicy) Phase(name string) *IlmPolicyPhase {
+	switch name {
+	case "hot":
+		return p.Hot
+	case "warm":
+		return p.Warm
+	case "cold":
+		return p.Cold
+	case "frozen":
+		return p.Frozen
+	case "delete":
+		return p.Delete
+	}
+
+	return nil
+}
+
+func (p *IlmPolicy) SlmPolicyNames() []string {
+	var names []string
+
+	for _, name := range ilmPhaseOrder {
+		phase := p.Phase(name)
+		if phase != nil && phase.Actions.WaitForSnapshot != nil {
+			names = append(names, phase.Actions.WaitForSnapshot.Policy)
 		}
 	}
 
-	if p.Warm > 0 && p.Cold > 0 && p.Delete > 0 {
-		schema["policy"]["phases"]["delete"] = PolicyPhase{
-			MinAge: fmt.Sprintf("%dd", p.Delete),
-			Actions: PolicyPhaseActions{
-				"delete": {},
-			},
+	return names
+}
+
+func (p *IlmPolicy) SnapshotRepositoryNames() []string {
+	var names []string
+
+	for _, name := range ilmPhaseOrder {
+		phase := p.Phase(name)
+		if phase != nil && phase.Actions.SearchableSnapshot != nil {
+			names = append(names, phase.Actions.SearchableSnapshot.SnapshotRepository)
 		}
 	}
 
-	return schema
+	return names
+}
+
+func (p *IlmPolicy) Validate() error {
+	previousPhase := ""
+	var previousMinAge time.Duration
+
+	for _, name := range ilmPhaseOrder {
+		phase := p.Phase(name)
+		if phase == nil {
+			continue
+		}
+
+		if err := phase.Actions.Validate(name); err != nil {
+			return fmt.Errorf("invalid [%s] phase: %w", name, err)
+		}
+
+		if phase.Priority != nil && *phase.Priority < 0 {
+			return fmt.Errorf("[%s] phase priority must be non-negative, got %d", name, *phase.Priority)
+		}
+
+		if phase.Priority != nil && (name == "frozen" || name == "delete") {
+			return fmt.Errorf("priority is not allowed in the [%s] phase", name)
+		}
+
+		if phase.OmitPriority && (name == "frozen" || name == "delete") {
+			return fmt.Errorf("setPriority is not allowed in the [%s] phase", name)
+		}
+
+		if phase.OmitPriority && phase.Priority != nil {
+			return fmt.Errorf("[%s] phase priority cannot be combined with setPriority: false", name)
+		}
+
+		minAge, err := phase.MinAge.Duration()
+		if err != nil {
+			return fmt.Errorf("invalid [%s] phase min_age: %w", name, err)
+		}
+
+		if previousPhase != "" && minAge < previousMinAge {
+			return fmt.Errorf("[%s] phase min_age [%s] is lower than [%s] phase min_age [%s]",
+				name,
+				phase.MinAge,
+				previousPhase,
+				p.Phase(previousPhase).MinAge,
+			)
+		}
+
+		previousPhase = name
+		previousMinAge = minAge
+	}
+
+	if p.Frozen != nil && p.Frozen.Actions.SearchableSnapshot == nil {
+		return errors.New("[frozen] phase requires a searchable_snapshot action")
+	}
+
+	if p.Hot != nil && p.Hot.Actions.SearchableSnapshot != nil {
+		for _, name := range ilmPhaseOrder[1:] {
+			phase := p.Phase(name)
+			if phase != nil && (phase.Actions.Shrink != nil || phase.Actions.Forcemerge != nil) {
+				return fmt.Errorf("shrink and forcemerge actions are not allowed in the [%s] phase "+
+					"after a searchable_snapshot action in the [hot] phase", name)
+			}
+		}
+	}
+
+	if repositories := p.SnapshotRepositoryNames(); len(repositories) > 0 {
+		for _, repository := range repositories[1:] {
+			if repository != repositories[0] {
+				return fmt.Errorf("all searchable_snapshot actions must use the same snapshot_repository, got [%s] and [%s]",
+					repositories[0],
+					repository,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *IlmPolicyPhase) schema(minAge string, priority int) PolicyPhase {
+	if p != nil && p.Priority != nil {
+		priority = *p.Priority
+	}
+
+	actions := PolicyPhaseActions{}
+
+	if p == nil || !p.OmitPriority {
+		actions["set_priority"] = map[string]any{
+			"priority": priority,
+		}
+	}
+
+	if p != nil {
+		for action, options := range p.Actions.schema() {
+			actions[action] = options
+		}
+	}
+
+	return PolicyPhase{
+		MinAge:  minAge,
+		Actions: actions,
+	}
+}
+
+func (p *IlmPolicyPhase) deleteSchema(minAge string) PolicyPhase {
+	actions := p.Actions.schema()
+	actions["delete"] = map[string]any{}
+
+	if p.Actions.DeleteSearchableSnapshot != nil {
+		actions["delete"]["delete_searchable_snapshot"] = *p.Actions.DeleteSearchableSnapshot
+	}
+
+	return PolicyPhase{
+		MinAge:  minAge,
+		Actions: actions,
+	}
+}
+
+var ilmPolicyActionDefaults = PolicyPhaseActions{
+	"searchable_snapshot": {
+		"force_merge_index": true,
+	},
+	"shrink": {
+		"allow_write_after_shrink": false,
+	},
+	"delete": {
+		"delete_searchable_snapshot": true,
+	},
+}
+
+func (s ImlPolicySchema) Normalize() ImlPolicySchema {
+	if s == nil {
+		return nil
+	}
+
+	normalized := ImlPolicySchema{}
+
+	for key, sections := range s {
+		normalized[key] = map[string]map[string]PolicyPhase{}
+
+		for section, phases := range sections {
+			normalized[key][section] = map[string]PolicyPhase{}
+
+			for name, phase := range phases {
+				normalized[key][section][name] = PolicyPhase{
+					MinAge:  phase.MinAge,
+					Actions: phase.Actions.withoutDefaults(),
+				}
+			}
+		}
+	}
+
+	return normalized
+}
+
+func (a PolicyPhaseActions) withoutDefaults() PolicyPhaseActions {
+	actions := PolicyPhaseActions{}
+
+	for action, options := range a {
+		if action == "set_priority" && options["priority"] == nil {
+			continue
+		}
+
+		actions[action] = map[string]any{}
+
+		for option, value := range options {
+			if defaultValue, ok := ilmPolicyActionDefaults[action][option]; ok && defaultValue == value {
+				continue
+			}
+
+			actions[action][option] = value
+		}
+	}
+
+	return actions
 }
@@ -0,0 +1,237 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ilmPhaseAllowedActions = map[string][]string{
+	"hot":    {"rollover", "shrink", "forcemerge", "searchable_snapshot", "readonly", "unfollow"},
+	"warm":   {"shrink", "forcemerge", "allocate", "migrate", "readonly", "unfollow"},
+	"cold":   {"searchable_snapshot", "allocate", "migrate", "readonly", "unfollow"},
+	"frozen": {"searchable_snapshot", "unfollow"},
+	"delete": {"wait_for_snapshot"},
+}
+
+type Shrink struct {
+	NumberOfShards        uint   `yaml:"number_of_shards"`
+	MaxPrimaryShardSize   string `yaml:"max_primary_shard_size"`
+	AllowWriteAfterShrink bool   `yaml:"allow_write_after_shrink"`
+}
+
+type Forcemerge struct {
+	MaxNumSegments uint   `yaml:"max_num_segments"`
+	IndexCodec     string `yaml:"index_codec"`
+}
+
+type Allocate struct {
+	NumberOfReplicas   *uint             `yaml:"number_of_replicas"`
+	TotalShardsPerNode *int              `yaml:"total_shards_per_node"`
+	Include            map[string]string `yaml:"include"`
+	Exclude            map[string]string `yaml:"exclude"`
+	Require            map[string]string `yaml:"require"`
+}
+
+type Migrate struct {
+	Enabled *bool `yaml:"enabled"`
+}
+
+type SearchableSnapshot struct {
+	SnapshotRepository string `yaml:"snapshot_repository"`
+	ForceMergeIndex    *bool  `yaml:"force_merge_index"`
+}
+
+type WaitForSnapshot struct {
+	Policy string `yaml:"policy"`
+}
+
+type IlmPolicyActions struct {
+	Rollover                 *Rollover           `yaml:"rollover"`
+	Shrink                   *Shrink             `yaml:"shrink"`
+	Forcemerge               *Forcemerge         `yaml:"forcemerge"`
+	SearchableSnapshot       *SearchableSnapshot `yaml:"searchable_snapshot"`
+	Allocate                 *Allocate           `yaml:"allocate"`
+	Migrate                  *Migrate            `yaml:"migrate"`
+	Readonly                 bool                `yaml:"readonly"`
+	Unfollow                 bool                `yaml:"unfollow"`
+	WaitForSnapshot          *WaitForSnapshot    `yaml:"wait_for_snapshot"`
+	DeleteSearchableSnapshot *bool               `yaml:"delete_searchable_snapshot"`
+}
+
+func (a *IlmPolicyActions) Validate(phase string) error {
+	allowed := map[string]bool{}
+	for _, action := range ilmPhaseAllowedActions[phase] {
+		allowed[action] = true
+	}
+
+	for action := range a.schema() {
+		if !allowed[action] {
+			return fmt.Errorf("action [%s] is not allowed in the [%s] phase", action, phase)
+		}
+	}
+
+	if a.Rollover != nil && !a.Rollover.HasMaxCondition() {
+		return errors.New("rollover requires at least one max_* condition")
+	}
+
+	if a.DeleteSearchableSnapshot != nil && phase != "delete" {
+		return errors.New("delete_searchable_snapshot is only allowed in the [delete] phase")
+	}
+
+	if phase == "hot" && a.Rollover == nil && (a.Shrink != nil || a.Forcemerge != nil || a.SearchableSnapshot != nil || a.Readonly) {
+		return errors.New("shrink, forcemerge, searchable_snapshot and readonly actions in the [hot] phase require a rollover action")
+	}
+
+	if a.Migrate != nil && a.Migrate.isEnabled() && a.Allocate != nil && a.Allocate.hasFilters() {
+		return fmt.Errorf("migrate and allocate with include, exclude or require cannot be combined in the [%s] phase, "+
+			"specify only a single data migration in each phase", phase)
+	}
+
+	if a.SearchableSnapshot != nil && a.SearchableSnapshot.SnapshotRepository == "" {
+		return errors.New("searchable_snapshot requires snapshot_repository")
+	}
+
+	if a.WaitForSnapshot != nil && a.WaitForSnapshot.Policy == "" {
+		return errors.New("wait_for_snapshot requires policy")
+	}
+
+	if a.Shrink != nil && (a.Shrink.NumberOfShards > 0) == (a.Shrink.MaxPrimaryShardSize != "") {
+		return errors.New("shrink requires exactly one of number_of_shards or max_primary_shard_size")
+	}
+
+	if a.Forcemerge != nil && a.Forcemerge.MaxNumSegments == 0 {
+		return errors.New("forcemerge requires max_num_segments greater than 0")
+	}
+
+	if a.Allocate != nil && len(a.Allocate.schema()) == 0 {
+		return errors.New("allocate requires at least one of number_of_replicas, total_shards_per_node, include, exclude or require")
+	}
+
+	return nil
+}
+
+func (a *IlmPolicyActions) schema() PolicyPhaseActions {
+	actions := PolicyPhaseActions{}
+
+	if a.Rollover != nil {
+		actions["rollover"] = a.Rollover.Conditions()
+	}
+
+	if a.Shrink != nil {
+		actions["shrink"] = a.Shrink.schema()
+	}
+
+	if a.Forcemerge != nil {
+		actions["forcemerge"] = a.Forcemerge.schema()
+	}
+
+	if a.SearchableSnapshot != nil {
+		actions["searchable_snapshot"] = a.SearchableSnapshot.schema()
+	}
+
+	if a.Allocate != nil {
+		actions["allocate"] = a.Allocate.schema()
+	}
+
+	if a.Migrate != nil {
+		actions["migrate"] = a.Migrate.schema()
+	}
+
+	if a.Readonly {
+		actions["readonly"] = map[string]any{}
+	}
+
+	if a.Unfollow {
+		actions["unfollow"] = map[string]any{}
+	}
+
+	if a.WaitForSnapshot != nil {
+		actions["wait_for_snapshot"] = map[string]any{
+			"policy": a.WaitForSnapshot.Policy,
+		}
+	}
+
+	return actions
+}
+
+func (s *Shrink) schema() map[string]any {
+	schema := map[string]any{}
+
+	if s.NumberOfShards > 0 {
+		schema["number_of_shards"] = s.NumberOfShards
+	}
+
+	if s.MaxPrimaryShardSize != "" {
+		schema["max_primary_shard_size"] = s.MaxPrimaryShardSize
+	}
+
+	if s.AllowWriteAfterShrink {
+		schema["allow_write_after_shrink"] = true
+	}
+
+	return schema
+}
+
+func (f *Forcemerge) schema() map[string]any {
+	schema := map[string]any{
+		"max_num_segments": f.MaxNumSegments,
+	}
+
+	if f.IndexCodec != "" {
+		schema["index_codec"] = f.IndexCodec
+	}
+
+	return schema
+}
+
+func (s *SearchableSnapshot) schema() map[string]any {
+	schema := map[string]any{
+		"snapshot_repository": s.SnapshotRepository,
+	}
+
+	if s.ForceMergeIndex != nil {
+		schema["force_merge_index"] = *s.ForceMergeIndex
+	}
+
+	return schema
+}
+
+func (a *Allocate) schema() map[string]any {
+	schema := map[string]any{}
+
+	if a.NumberOfReplicas != nil {
+		schema["number_of_replicas"] = *a.NumberOfReplicas
+	}
+
+	if a.TotalShardsPerNode != nil {
+		schema["total_shards_per_node"] = *a.TotalShardsPerNode
+	}
+
+	for name, attributes := range map[string]map[string]string{
+		"include": a.Include,
+		"exclude": a.Exclude,
+		"require": a.Require,
+	} {
+		if len(attributes) > 0 {
+			schema[name] = attributes
+		}
+	}
+
+	return schema
+}
+
+func (m *Migrate) isEnabled() bool {
+	return m.Enabled == nil || *m.Enabled
+}
+
+func (m *Migrate) schema() map[string]any {
+	enabled := m.isEnabled()
+
+	return map[string]any{
+		"enabled": enabled,
+	}
+}
+
+func (a *Allocate) hasFilters() bool {
+	return len(a.Include) > 0 || len(a.Exclude) > 0 || len(a.Require) > 0
+}
@@ -0,0 +1,126 @@
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIlmPolicyActions_Validate(t *testing.T) {
+	replicas := uint(1)
+	disabled := false
+
+	validCases := map[string]struct {
+		phase   string
+		actions IlmPolicyActions
+	}{
+		"hot phase with rollover and forcemerge": {"hot", IlmPolicyActions{
+			Rollover:   &Rollover{MaxAge: "1d"},
+			Forcemerge: &Forcemerge{MaxNumSegments: 1},
+		}},
+		"warm phase with all actions": {"warm", IlmPolicyActions{
+			Shrink:     &Shrink{NumberOfShards: 1},
+			Forcemerge: &Forcemerge{MaxNumSegments: 1},
+			Allocate:   &Allocate{NumberOfReplicas: &replicas},
+			Migrate:    &Migrate{},
+			Readonly:   true,
+			Unfollow:   true,
+		}},
+		"cold phase with allocate": {"cold", IlmPolicyActions{
+			Allocate: &Allocate{Require: map[string]string{"box_type": "cold"}},
+		}},
+		"delete phase without actions": {"delete", IlmPolicyActions{}},
+		"delete phase with wait for snapshot": {"delete", IlmPolicyActions{
+			WaitForSnapshot: &WaitForSnapshot{Policy: "nightly-snapshots"},
+		}},
+		"frozen phase with searchable snapshot": {"frozen", IlmPolicyActions{
+			SearchableSnapshot: &SearchableSnapshot{SnapshotRepository: "backups"},
+		}},
+		"hot phase with rollover and readonly": {"hot", IlmPolicyActions{
+			Rollover: &Rollover{MaxAge: "1d"},
+			Readonly: true,
+		}},
+		"cold phase with disabled migrate and allocate": {"cold", IlmPolicyActions{
+			Allocate: &Allocate{Require: map[string]string{"box_type": "cold"}},
+			Migrate:  &Migrate{Enabled: &disabled},
+		}},
+	}
+
+	for name, c := range validCases {
+		t.Run(name, func(t *testing.T) {
+			if err := c.actions.Validate(c.phase); err != nil {
+				t.Errorf("expected valid actions, got %v", err)
+			}
+		})
+	}
+
+	invalidCases := map[string]struct {
+		phase   string
+		actions IlmPolicyActions
+	}{
+		"rollover in warm phase":             {"warm", IlmPolicyActions{Rollover: &Rollover{MaxAge: "1d"}}},
+		"shrink in cold phase":               {"cold", IlmPolicyActions{Shrink: &Shrink{NumberOfShards: 1}}},
+		"allocate in hot phase":              {"hot", IlmPolicyActions{Allocate: &Allocate{NumberOfReplicas: &replicas}}},
+		"readonly in delete phase":           {"delete", IlmPolicyActions{Readonly: true}},
+		"rollover without max conditions":    {"hot", IlmPolicyActions{Rollover: &Rollover{MinDocs: 1}}},
+		"hot forcemerge without rollover":    {"hot", IlmPolicyActions{Forcemerge: &Forcemerge{MaxNumSegments: 1}}},
+		"shrink without target":              {"warm", IlmPolicyActions{Shrink: &Shrink{}}},
+		"shrink with both targets":           {"warm", IlmPolicyActions{Shrink: &Shrink{NumberOfShards: 1, MaxPrimaryShardSize: "50gb"}}},
+		"forcemerge without max_num_segment": {"warm", IlmPolicyActions{Forcemerge: &Forcemerge{}}},
+		"allocate without options":           {"warm", IlmPolicyActions{Allocate: &Allocate{}}},
+		"searchable snapshot in warm phase":  {"warm", IlmPolicyActions{SearchableSnapshot: &SearchableSnapshot{SnapshotRepository: "backups"}}},
+		"searchable snapshot without repo":   {"cold", IlmPolicyActions{SearchableSnapshot: &SearchableSnapshot{}}},
+		"wait for snapshot without policy":   {"delete", IlmPolicyActions{WaitForSnapshot: &WaitForSnapshot{}}},
+		"hot readonly without rollover":      {"hot", IlmPolicyActions{Readonly: true}},
+		"migrate and allocate with require": {"warm", IlmPolicyActions{
+			Allocate: &Allocate{Require: map[string]string{"box_type": "warm"}},
+			Migrate:  &Migrate{},
+		}},
+	}
+
+	for name, c := range invalidCases {
+		t.Run(name, func(t *testing.T) {
+			if err := c.actions.Validate(c.phase); err == nil {
+				t.Errorf("expected invalid actions")
+			}
+		})
+	}
+}
+
+func TestIlmPolicyActions_Schema(t *testing.T) {
+	replicas := uint(0)
+	disabled := false
+
+	expected := PolicyPhaseActions{
+		"shrink": {
+			"max_primary_shard_size": "50gb",
+		},
+		"forcemerge": {
+			"max_num_segments": uint(1),
+			"index_codec":      "best_compression",
+		},
+		"allocate": {
+			"number_of_replicas": uint(0),
+			"require":            map[string]string{"box_type": "warm"},
+		},
+		"migrate": {
+			"enabled": false,
+		},
+		"readonly": {},
+		"unfollow": {},
+	}
+
+	actions := IlmPolicyActions{
+		Shrink:     &Shrink{MaxPrimaryShardSize: "50gb"},
+		Forcemerge: &Forcemerge{MaxNumSegments: 1, IndexCodec: "best_compression"},
+		Allocate:   &Allocate{NumberOfReplicas: &replicas, Require: map[string]string{"box_type": "warm"}},
+		Migrate:    &Migrate{Enabled: &disabled},
+		Readonly:   true,
+		Unfollow:   true,
+	}
+
+	actual := actions.schema()
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("actual %v\nwant %v", actual, expected)
+	}
+}
@@ -29,6 +29,46 @@ func TestIlmPolicy_Schema(t *testing.T) {
 		}
 	})
 
+	t.Run("ILM with hot phase rollover", func(t *testing.T) {
+		expected := ImlPolicySchema{
+			"policy": {
+				"phases": {
+					"hot": PolicyPhase{
+						MinAge: "0ms",
+						Actions: PolicyPhaseActions{
+							"set_priority": {
+								"priority": 100,
+							},
+							"rollover": {
+								"max_age":                "30d",
+								"max_primary_shard_size": "50gb",
+								"min_docs":               uint64(1),
+							},
+						},
+					},
+				},
+			},
+		}
+
+		policy := &IlmPolicy{
+			Hot: &IlmPolicyPhase{
+				Actions: IlmPolicyActions{
+					Rollover: &Rollover{
+						MaxAge:              "30d",
+						MaxPrimaryShardSize: "50gb",
+						MinDocs:             1,
+					},
+				},
+			},
+		}
+
+		actual := policy.Schema()
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("actual %v\nwant %v", actual, expected)
+		}
+	})
+
 	t.Run("ILM with warm phase", func(t *testing.T) {
 		expected := ImlPolicySchema{
 			"policy": {
@@ -54,7 +94,7 @@ func TestIlmPolicy_Schema(t *testing.T) {
 		}
 
 		policy := &IlmPolicy{
-			Warm: 1,
+			Warm: &IlmPolicyPhase{MinAge: "1d"},
 		}
 
 		actual := policy.Schema()
@@ -97,8 +137,8 @@ func TestIlmPolicy_Schema(t *testing.T) {
 		}
 
 		policy := &IlmPolicy{
-			Warm: 1,
-			Cold: 7,
+			Warm: &IlmPolicyPhase{MinAge: "1d"},
+			Cold: &IlmPolicyPhase{MinAge: "7d"},
 		}
 
 		actual := policy.Schema()
@@ -147,9 +187,9 @@ func TestIlmPolicy_Schema(t *testing.T) {
 		}
 
 		policy := &IlmPolicy{
-			Warm:   1,
-			Cold:   7,
-			Delete: 30,
+			Warm:   &IlmPolicyPhase{MinAge: "1d"},
+			Cold:   &IlmPolicyPhase{MinAge: "7d"},
+			Delete: &IlmPolicyPhase{MinAge: "30d"},
 		}
 
 		actual := policy.Schema()
@@ -179,13 +219,19 @@ func TestIlmPolicy_Schema(t *testing.T) {
 							},
 						},
 					},
+					"delete": PolicyPhase{
+						MinAge: "30d",
+						Actions: PolicyPhaseActions{
+							"delete": {},
+						},
+					},
 				},
 			},
 		}
 
 		policy := &IlmPolicy{
-			Warm:   1,
-			Delete: 30,
+			Warm:   &IlmPolicyPhase{MinAge: "1d"},
+			Delete: &IlmPolicyPhase{MinAge: "30d"},
 		}
 
 		actual := policy.Schema()
@@ -207,12 +253,20 @@ func TestIlmPolicy_Schema(t *testing.T) {
 							},
 						},
 					},
+					"cold": PolicyPhase{
+						MinAge: "1d",
+						Actions: PolicyPhaseActions{
+							"set_priority": {
+								"priority": 0,
+							},
+						},
+					},
 				},
 			},
 		}
 
 		policy := &IlmPolicy{
-			Cold: 1,
+			Cold: &IlmPolicyPhase{MinAge: "1d"},
 		}
 
 		actual := policy.Schema()
@@ -234,12 +288,18 @@ func TestIlmPolicy_Schema(t *testing.T) {
 							},
 						},
 					},
+					"delete": PolicyPhase{
+						MinAge: "1d",
+						Actions: PolicyPhaseActions{
+							"delete": {},
+						},
+					},
 				},
 			},
 		}
 
 		policy := &IlmPolicy{
-			Delete: 1,
+			Delete: &IlmPolicyPhase{MinAge: "1d"},
 		}
 
 		actual := policy.Schema()
@@ -261,13 +321,27 @@ func TestIlmPolicy_Schema(t *testing.T) {
 							},
 						},
 					},
+					"cold": PolicyPhase{
+						MinAge: "1d",
+						Actions: PolicyPhaseActions{
+							"set_priority": {
+								"priority": 0,
+							},
+						},
+					},
+					"delete": PolicyPhase{
+						MinAge: "2d",
+						Actions: PolicyPhaseActions{
+							"delete": {},
+						},
+					},
 				},
 			},
 		}
 
 		policy := &IlmPolicy{
-			Cold:   1,
-			Delete: 2,
+			Cold:   &IlmPolicyPhase{MinAge: "1d"},
+			Delete: &IlmPolicyPhase{MinAge: "2d"},
 		}
 
 		actual := policy.Schema()
@@ -277,3 +351,465 @@ func TestIlmPolicy_Schema(t *testing.T) {
 		}
 	})
 }
+
+func TestIlmPolicy_SchemaWithHotMinAge(t *testing.T) {
+	policy := &IlmPolicy{
+		Hot: &IlmPolicyPhase{MinAge: "1h"},
+	}
+
+	actual := policy.Schema()["policy"]["phases"]["hot"].MinAge
+
+	if actual != "1h" {
+		t.Errorf("actual %v\nwant %v", actual, "1h")
+	}
+}
+
+func TestIlmPolicy_SchemaWithSnapshots(t *testing.T) {
+	t.Run("ILM with frozen phase and snapshot aware delete phase", func(t *testing.T) {
+		forceMergeIndex := false
+		deleteSearchableSnapshot := false
+
+		expected := ImlPolicySchema{
+			"policy": {
+				"phases": {
+					"hot": PolicyPhase{
+						MinAge: "0ms",
+						Actions: PolicyPhaseActions{
+							"set_priority": {
+								"priority": 100,
+							},
+						},
+					},
+					"warm": PolicyPhase{
+						MinAge: "1d",
+						Actions: PolicyPhaseActions{
+							"set_priority": {
+								"priority": 50,
+							},
+						},
+					},
+					"cold": PolicyPhase{
+						MinAge: "7d",
+						Actions: PolicyPhaseActions{
+							"set_priority": {
+								"priority": 0,
+							},
+							"searchable_snapshot": {
+								"snapshot_repository": "backups",
+							},
+						},
+					},
+					"frozen": PolicyPhase{
+						MinAge: "30d",
+						Actions: PolicyPhaseActions{
+							"searchable_snapshot": {
+								"snapshot_repository": "backups",
+								"force_merge_index":   false,
+							},
+						},
+					},
+					"delete": PolicyPhase{
+						MinAge: "90d",
+						Actions: PolicyPhaseActions{
+							"wait_for_snapshot": {
+								"policy": "nightly-snapshots",
+							},
+							"delete": {
+								"delete_searchable_snapshot": false,
+							},
+						},
+					},
+				},
+			},
+		}
+
+		policy := &IlmPolicy{
+			Warm: &IlmPolicyPhase{MinAge: "1d"},
+			Cold: &IlmPolicyPhase{
+				MinAge: "7d",
+				Actions: IlmPolicyActions{
+					SearchableSnapshot: &SearchableSnapshot{SnapshotRepository: "backups"},
+				},
+			},
+			Frozen: &IlmPolicyPhase{
+				MinAge: "30d",
+				Actions: IlmPolicyActions{
+					SearchableSnapshot: &SearchableSnapshot{
+						SnapshotRepository: "backups",
+						ForceMergeIndex:    &forceMergeIndex,
+					},
+				},
+			},
+			Delete: &IlmPolicyPhase{
+				MinAge: "90d",
+				Actions: IlmPolicyActions{
+					WaitForSnapshot:          &WaitForSnapshot{Policy: "nightly-snapshots"},
+					DeleteSearchableSnapshot: &deleteSearchableSnapshot,
+				},
+			},
+		}
+
+		actual := policy.Schema()
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("actual %v\nwant %v", actual, expected)
+		}
+	})
+}
+
+func TestIlmPolicy_SchemaWithPriorities(t *testing.T) {
+	hotPriority := 200
+	coldPriority := 0
+
+	expected := ImlPolicySchema{
+		"policy": {
+			"phases": {
+				"hot": PolicyPhase{
+					MinAge: "0ms",
+					Actions: PolicyPhaseActions{
+						"set_priority": {
+							"priority": 200,
+						},
+					},
+				},
+				"warm": PolicyPhase{
+					MinAge: "1d",
+					Actions: PolicyPhaseActions{
+						"set_priority": {
+							"priority": 50,
+						},
+					},
+				},
+				"cold": PolicyPhase{
+					MinAge: "7d",
+					Actions: PolicyPhaseActions{
+						"set_priority": {
+							"priority": 0,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	policy := &IlmPolicy{
+		Hot:  &IlmPolicyPhase{Priority: &hotPriority},
+		Warm: &IlmPolicyPhase{MinAge: "1d"},
+		Cold: &IlmPolicyPhase{MinAge: "7d", Priority: &coldPriority},
+	}
+
+	actual := policy.Schema()
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("actual %v\nwant %v", actual, expected)
+	}
+}
+
+func TestIlmPolicy_SchemaWithoutPriorities(t *testing.T) {
+	expected := ImlPolicySchema{
+		"policy": {
+			"phases": {
+				"hot": PolicyPhase{
+					MinAge:  "0ms",
+					Actions: PolicyPhaseActions{},
+				},
+				"warm": PolicyPhase{
+					MinAge: "1d",
+					Actions: PolicyPhaseActions{
+						"readonly": {},
+					},
+				},
+			},
+		},
+	}
+
+	policy := &IlmPolicy{
+		Hot:  &IlmPolicyPhase{OmitPriority: true},
+		Warm: &IlmPolicyPhase{MinAge: "1d", OmitPriority: true, Actions: IlmPolicyActions{Readonly: true}},
+	}
+
+	actual := policy.Schema()
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("actual %v\nwant %v", actual, expected)
+	}
+}
+
+func TestIlmPolicy_Validate(t *testing.T) {
+	snapshot := &SearchableSnapshot{SnapshotRepository: "backups"}
+
+	t.Run("Frozen phase with searchable snapshot", func(t *testing.T) {
+		policy := &IlmPolicy{
+			Frozen: &IlmPolicyPhase{MinAge: "30d", Actions: IlmPolicyActions{SearchableSnapshot: snapshot}},
+		}
+
+		if err := policy.Validate(); err != nil {
+			t.Errorf("expected valid policy, got %v", err)
+		}
+	})
+
+	t.Run("Phases with increasing min_age", func(t *testing.T) {
+		policy := &IlmPolicy{
+			Warm:   &IlmPolicyPhase{MinAge: "7d"},
+			Delete: &IlmPolicyPhase{MinAge: "30d"},
+		}
+
+		if err := policy.Validate(); err != nil {
+			t.Errorf("expected valid policy, got %v", err)
+		}
+	})
+
+	t.Run("Phases with decreasing min_age", func(t *testing.T) {
+		policy := &IlmPolicy{
+			Warm:   &IlmPolicyPhase{MinAge: "30d"},
+			Cold:   &IlmPolicyPhase{MinAge: "60d"},
+			Delete: &IlmPolicyPhase{MinAge: "7d"},
+		}
+
+		if err := policy.Validate(); err == nil {
+			t.Errorf("delete phase min_age lower than cold phase min_age should fail")
+		}
+	})
+
+	t.Run("Hot phase min_age higher than warm phase min_age", func(t *testing.T) {
+		policy := &IlmPolicy{
+			Hot:  &IlmPolicyPhase{MinAge: "10d"},
+			Warm: &IlmPolicyPhase{MinAge: "7d"},
+		}
+
+		if err := policy.Validate(); err == nil {
+			t.Errorf("warm phase min_age lower than hot phase min_age should fail")
+		}
+	})
+
+	t.Run("Phases with mixed min_age units", func(t *testing.T) {
+		policy := &IlmPolicy{
+			Warm:   &IlmPolicyPhase{MinAge: "12h"},
+			Cold:   &IlmPolicyPhase{MinAge: "1"},
+			Delete: &IlmPolicyPhase{MinAge: "1440m"},
+		}
+
+		if err := policy.Validate(); err != nil {
+			t.Errorf("expected valid policy, got %v", err)
+		}
+
+		policy.Delete.MinAge = "23h"
+
+		if err := policy.Validate(); err == nil {
+			t.Errorf("delete phase min_age lower than cold phase min_age should fail")
+		}
+	})
+
+	t.Run("Phase with negative priority", func(t *testing.T) {
+		priority := -1
+		policy := &IlmPolicy{
+			Warm: &IlmPolicyPhase{MinAge: "1d", Priority: &priority},
+		}
+
+		if err := policy.Validate(); err == nil {
+			t.Errorf("negative priority should fail")
+		}
+	})
+
+	t.Run("Phase with priority and omitted priority", func(t *testing.T) {
+		priority := 10
+		policy := &IlmPolicy{
+			Warm: &IlmPolicyPhase{MinAge: "1d", Priority: &priority, OmitPriority: true},
+		}
+
+		if err := policy.Validate(); err == nil {
+			t.Errorf("priority combined with omitted priority should fail")
+		}
+	})
+
+	t.Run("Delete phase with omitted priority", func(t *testing.T) {
+		policy := &IlmPolicy{
+			Delete: &IlmPolicyPhase{MinAge: "30d", OmitPriority: true},
+		}
+
+		if err := policy.Validate(); err == nil {
+			t.Errorf("omitted priority in delete phase should fail")
+		}
+	})
+
+	t.Run("Delete phase with priority", func(t *testing.T) {
+		priority := 10
+		policy := &IlmPolicy{
+			Delete: &IlmPolicyPhase{MinAge: "30d", Priority: &priority},
+		}
+
+		if err := policy.Validate(); err == nil {
+			t.Errorf("priority in delete phase should fail")
+		}
+	})
+
+	t.Run("Phase with invalid min_age", func(t *testing.T) {
+		policy := &IlmPolicy{
+			Warm: &IlmPolicyPhase{MinAge: "1w"},
+		}
+
+		if err := policy.Validate(); err == nil {
+			t.Errorf("invalid min_age should fail")
+		}
+	})
+
+	t.Run("Frozen phase without searchable snapshot", func(t *testing.T) {
+		policy := &IlmPolicy{
+			Frozen: &IlmPolicyPhase{MinAge: "30d"},
+		}
+
+		if err := policy.Validate(); err == nil {
+			t.Errorf("frozen phase without searchable_snapshot should fail")
+		}
+	})
+
+	t.Run("Delete searchable snapshot outside of delete phase", func(t *testing.T) {
+		deleteSearchableSnapshot := true
+		policy := &IlmPolicy{
+			Cold: &IlmPolicyPhase{MinAge: "30d", Actions: IlmPolicyActions{DeleteSearchableSnapshot: &deleteSearchableSnapshot}},
+		}
+
+		if err := policy.Validate(); err == nil {
+			t.Errorf("delete_searchable_snapshot in cold phase should fail")
+		}
+	})
+
+	t.Run("Forcemerge after hot phase searchable snapshot", func(t *testing.T) {
+		policy := &IlmPolicy{
+			Hot: &IlmPolicyPhase{Actions: IlmPolicyActions{
+				Rollover:           &Rollover{MaxAge: "1d"},
+				SearchableSnapshot: snapshot,
+			}},
+			Warm: &IlmPolicyPhase{MinAge: "1d", Actions: IlmPolicyActions{Forcemerge: &Forcemerge{MaxNumSegments: 1}}},
+		}
+
+		if err := policy.Validate(); err == nil {
+			t.Errorf("forcemerge after hot phase searchable_snapshot should fail")
+		}
+	})
+
+	t.Run("Shrink after hot phase searchable snapshot", func(t *testing.T) {
+		policy := &IlmPolicy{
+			Hot: &IlmPolicyPhase{Actions: IlmPolicyActions{
+				Rollover:           &Rollover{MaxAge: "1d"},
+				SearchableSnapshot: snapshot,
+			}},
+			Warm: &IlmPolicyPhase{MinAge: "1d", Actions: IlmPolicyActions{Shrink: &Shrink{NumberOfShards: 1}}},
+		}
+
+		if err := policy.Validate(); err == nil {
+			t.Errorf("shrink after hot phase searchable_snapshot should fail")
+		}
+	})
+
+	t.Run("Hot and cold searchable snapshots on the same repository", func(t *testing.T) {
+		policy := &IlmPolicy{
+			Hot: &IlmPolicyPhase{Actions: IlmPolicyActions{
+				Rollover:           &Rollover{MaxAge: "1d"},
+				SearchableSnapshot: snapshot,
+			}},
+			Cold: &IlmPolicyPhase{MinAge: "30d", Actions: IlmPolicyActions{SearchableSnapshot: snapshot}},
+		}
+
+		if err := policy.Validate(); err != nil {
+			t.Errorf("expected valid policy, got %v", err)
+		}
+	})
+
+	t.Run("Searchable snapshots on different repositories", func(t *testing.T) {
+		policy := &IlmPolicy{
+			Cold: &IlmPolicyPhase{MinAge: "30d", Actions: IlmPolicyActions{
+				SearchableSnapshot: &SearchableSnapshot{SnapshotRepository: "a"},
+			}},
+			Frozen: &IlmPolicyPhase{MinAge: "60d", Actions: IlmPolicyActions{
+				SearchableSnapshot: &SearchableSnapshot{SnapshotRepository: "b"},
+			}},
+		}
+
+		if err := policy.Validate(); err == nil {
+			t.Errorf("searchable_snapshot actions on different repositories should fail")
+		}
+	})
+}
+
+func TestImlPolicySchema_Normalize(t *testing.T) {
+	t.Run("Nil schema stays nil", func(t *testing.T) {
+		if actual := ImlPolicySchema(nil).Normalize(); actual != nil {
+			t.Errorf("actual %v\nwant nil", actual)
+		}
+	})
+
+	t.Run("Server defaults are removed", func(t *testing.T) {
+		expected := ImlPolicySchema{
+			"policy": {
+				"phases": {
+					"delete": PolicyPhase{
+						MinAge: "30d",
+						Actions: PolicyPhaseActions{
+							"delete": {},
+						},
+					},
+				},
+			},
+		}
+
+		schema := ImlPolicySchema{
+			"policy": {
+				"phases": {
+					"delete": PolicyPhase{
+						MinAge: "30d",
+						Actions: PolicyPhaseActions{
+							"delete": {
+								"delete_searchable_snapshot": true,
+							},
+						},
+					},
+				},
+			},
+		}
+
+		actual := schema.Normalize()
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("actual %v\nwant %v", actual, expected)
+		}
+
+		if len(schema["policy"]["phases"]["delete"].Actions["delete"]) != 1 {
+			t.Errorf("original schema should not be modified")
+		}
+	})
+
+	t.Run("Unset priority is removed", func(t *testing.T) {
+		schema := ImlPolicySchema{
+			"policy": {
+				"phases": {
+					"warm": PolicyPhase{
+						MinAge: "1d",
+						Actions: PolicyPhaseActions{
+							"set_priority": {
+								"priority": nil,
+							},
+						},
+					},
+				},
+			},
+		}
+
+		expected := ImlPolicySchema{
+			"policy": {
+				"phases": {
+					"warm": PolicyPhase{
+						MinAge:  "1d",
+						Actions: PolicyPhaseActions{},
+					},
+				},
+			},
+		}
+
+		actual := schema.Normalize()
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("actual %v\nwant %v", actual, expected)
+		}
+	})
+}
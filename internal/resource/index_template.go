@@ -1,35 +1,149 @@
 package resource
 
+const IndexTemplateKind = "index template"
+
 type IndexTemplate struct {
-	Name          string   `yaml:"name"`
-	Patterns      []string `yaml:"patterns"`
-	IlmPolicyName string   `yaml:"policy"`
+	Name            string                   `yaml:"name"`
+	Patterns        []string                 `yaml:"patterns"`
+	IlmPolicyName   string                   `yaml:"policy"`
+	ComposedOf      []string                 `yaml:"composed_of"`
+	Settings        Settings                 `yaml:"settings"`
+	Mappings        map[string]any           `yaml:"mappings"`
+	Aliases         map[string]any           `yaml:"aliases"`
+	Priority        *uint                    `yaml:"priority"`
+	Version         *int                     `yaml:"version"`
+	Meta            map[string]any           `yaml:"_meta"`
+	DataStream      *IndexTemplateDataStream `yaml:"dataStream"`
+	RolloverAlias   string                   `yaml:"rolloverAlias"`
+	DefaultPipeline string                   `yaml:"defaultPipeline"`
+	FinalPipeline   string                   `yaml:"finalPipeline"`
 }
 
-type IndexTemplateSchemaTemplate map[string]map[string]map[string]map[string]string
-
 type IndexTemplateSchema struct {
-	IndexPatterns []string                    `json:"index_patterns"`
-	Template      IndexTemplateSchemaTemplate `json:"template"`
+	IndexPatterns []string          `json:"index_patterns"`
+	Template      *TemplateSchema   `json:"template,omitempty"`
+	ComposedOf    []string          `json:"composed_of,omitempty"`
+	Priority      *uint             `json:"priority,omitempty"`
+	Version       *int              `json:"version,omitempty"`
+	Meta          map[string]any    `json:"_meta,omitempty"`
+	DataStream    *DataStreamSchema `json:"data_stream,omitempty"`
 }
 
 func (t *IndexTemplate) Schema() IndexTemplateSchema {
 	indexTemplateSchema := IndexTemplateSchema{
 		IndexPatterns: t.Patterns,
 		Template:      nil,
+		ComposedOf:    t.ComposedOf,
+		Priority:      t.Priority,
+		Version:       t.Version,
+		Meta:          t.Meta,
+		DataStream:    t.DataStream.Schema(),
 	}
 
+	settings := t.Settings
 	if t.IlmPolicyName != "" {
-		indexTemplateSchema.Template = IndexTemplateSchemaTemplate{
-			"settings": {
-				"index": {
-					"lifecycle": {
-						"name": t.IlmPolicyName,
-					},
-				},
-			},
+		settings = settings.With("index.lifecycle.name", t.IlmPolicyName)
+	}
+
+	if t.RolloverAlias != "" {
+		settings = settings.With("index.lifecycle.rollover_alias", t.RolloverAlias)
+	}
+
+	if t.DefaultPipeline != "" {
+		settings = settings.With("index.default_pipeline", t.DefaultPipeline)
+	}
+
+	if t.FinalPipeline != "" {
+		settings = settings.With("index.final_pipeline", t.FinalPipeline)
+	}
+
+	if len(settings) > 0 || len(t.Mappings) > 0 || len(t.Aliases) > 0 {
+		indexTemplateSchema.Template = &TemplateSchema{
+			Settings: settings,
+			Mappings: t.Mappings,
+			Aliases:  t.Aliases,
 		}
 	}
 
 	return indexTemplateSchema
 }
+
+func (t *IndexTemplate) BootstrapIndex() *BootstrapIndex {
+	if t.RolloverAlias == "" {
+		return nil
+	}
+
+	return NewBootstrapIndex(t.RolloverAlias, t.Name)
+}
+
+func (s *IndexTemplateSchema) Normalize() *IndexTemplateSchema {
+	if s == nil {
+		return nil
+	}
+
+	normalized := &IndexTemplateSchema{
+		IndexPatterns: s.IndexPatterns,
+		ComposedOf:    s.ComposedOf,
+		Priority:      s.Priority,
+		Version:       s.Version,
+		Meta:          s.Meta,
+		DataStream:    s.DataStream,
+	}
+
+	if s.Template != nil {
+		template := s.Template.Normalize()
+		normalized.Template = &template
+	}
+
+	return normalized
+}
+
+func (t *IndexTemplate) EffectivePriority() uint {
+	if t.Priority == nil {
+		return 0
+	}
+
+	return *t.Priority
+}
+
+func (t *IndexTemplate) Overlaps(other *IndexTemplate) bool {
+	for _, pattern := range t.Patterns {
+		for _, otherPattern := range other.Patterns {
+			if patternsIntersect(pattern, otherPattern) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (t *IndexTemplate) Matches(indexName string) bool {
+	for _, pattern := range t.Patterns {
+		if patternsIntersect(pattern, indexName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func patternsIntersect(a string, b string) bool {
+	if a == "" && b == "" {
+		return true
+	}
+
+	if a != "" && a[0] == '*' {
+		return patternsIntersect(a[1:], b) || (b != "" && patternsIntersect(a, b[1:]))
+	}
+
+	if b != "" && b[0] == '*' {
+		return patternsIntersect(a, b[1:]) || (a != "" && patternsIntersect(a[1:], b))
+	}
+
+	if a == "" || b == "" {
+		return false
+	}
+
+	return a[0] == b[0] && patternsIntersect(a[1:], b[1:])
+}
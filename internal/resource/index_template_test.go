@@ -35,10 +35,10 @@ func TestIndexTemplate_Schema(t *testing.T) {
 	t.Run("Index template with patterns and policy", func(t *testing.T) {
 		expected := IndexTemplateSchema{
 			IndexPatterns: []string{"pattern-a", "pattern-b"},
-			Template: IndexTemplateSchemaTemplate{
-				"settings": {
-					"index": {
-						"lifecycle": {
+			Template: &TemplateSchema{
+				Settings: Settings{
+					"index": map[string]any{
+						"lifecycle": map[string]any{
 							"name": "policy-name",
 						},
 					},
@@ -57,4 +57,230 @@ func TestIndexTemplate_Schema(t *testing.T) {
 			t.Errorf("expected: %v, actual: %v", expected, actual)
 		}
 	})
+	t.Run("Index template composed of component templates", func(t *testing.T) {
+		expected := IndexTemplateSchema{
+			IndexPatterns: []string{"pattern-a"},
+			ComposedOf:    []string{"component-a", "component-b"},
+		}
+
+		indexTemplate := &IndexTemplate{
+			Patterns:   []string{"pattern-a"},
+			ComposedOf: []string{"component-a", "component-b"},
+		}
+
+		actual := indexTemplate.Schema()
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("expected: %v, actual: %v", expected, actual)
+		}
+	})
+	t.Run("Index template with settings, mappings, aliases and policy", func(t *testing.T) {
+		expected := IndexTemplateSchema{
+			IndexPatterns: []string{"pattern-a"},
+			Template: &TemplateSchema{
+				Settings: Settings{
+					"number_of_shards": 3,
+					"index": map[string]any{
+						"codec": "best_compression",
+						"lifecycle": map[string]any{
+							"name":           "policy-name",
+							"rollover_alias": "alias",
+						},
+					},
+				},
+				Mappings: map[string]any{
+					"dynamic": false,
+				},
+				Aliases: map[string]any{
+					"alias": map[string]any{},
+				},
+			},
+		}
+
+		settings := Settings{
+			"number_of_shards": 3,
+			"index": map[string]any{
+				"codec": "best_compression",
+				"lifecycle": map[string]any{
+					"rollover_alias": "alias",
+				},
+			},
+		}
+
+		indexTemplate := &IndexTemplate{
+			Patterns:      []string{"pattern-a"},
+			IlmPolicyName: "policy-name",
+			Settings:      settings,
+			Mappings:      map[string]any{"dynamic": false},
+			Aliases:       map[string]any{"alias": map[string]any{}},
+		}
+
+		actual := indexTemplate.Schema()
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("expected: %v, actual: %v", expected, actual)
+		}
+
+		if settings.Has("index.lifecycle.name") {
+			t.Errorf("user settings should not be modified")
+		}
+	})
+	t.Run("Index template with priority, version and meta", func(t *testing.T) {
+		priority := uint(200)
+		version := 3
+
+		expected := IndexTemplateSchema{
+			IndexPatterns: []string{"pattern-a"},
+			Priority:      &priority,
+			Version:       &version,
+			Meta:          map[string]any{"owner": "team-a"},
+		}
+
+		indexTemplate := &IndexTemplate{
+			Patterns: []string{"pattern-a"},
+			Priority: &priority,
+			Version:  &version,
+			Meta:     map[string]any{"owner": "team-a"},
+		}
+
+		actual := indexTemplate.Schema()
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("expected: %v, actual: %v", expected, actual)
+		}
+	})
+
+	t.Run("Index template with rollover alias", func(t *testing.T) {
+		expected := IndexTemplateSchema{
+			IndexPatterns: []string{"logs-*"},
+			Template: &TemplateSchema{
+				Settings: Settings{
+					"index": map[string]any{
+						"lifecycle": map[string]any{
+							"name":           "policy",
+							"rollover_alias": "logs",
+						},
+					},
+				},
+			},
+		}
+
+		indexTemplate := &IndexTemplate{
+			Name:          "template",
+			Patterns:      []string{"logs-*"},
+			IlmPolicyName: "policy",
+			RolloverAlias: "logs",
+		}
+
+		actual := indexTemplate.Schema()
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("expected: %v, actual: %v", expected, actual)
+		}
+
+		expectedBootstrapIndex := &BootstrapIndex{
+			Name:          "logs-000001",
+			Alias:         "logs",
+			IndexTemplate: "template",
+		}
+
+		if !reflect.DeepEqual(expectedBootstrapIndex, indexTemplate.BootstrapIndex()) {
+			t.Errorf("expected: %v, actual: %v", expectedBootstrapIndex, indexTemplate.BootstrapIndex())
+		}
+	})
+
+	t.Run("Index template with ingest pipelines", func(t *testing.T) {
+		expected := IndexTemplateSchema{
+			IndexPatterns: []string{"logs-*"},
+			Template: &TemplateSchema{
+				Settings: Settings{
+					"index": map[string]any{
+						"default_pipeline": "parse",
+						"final_pipeline":   "enrich",
+					},
+				},
+			},
+		}
+
+		indexTemplate := &IndexTemplate{
+			Patterns:        []string{"logs-*"},
+			DefaultPipeline: "parse",
+			FinalPipeline:   "enrich",
+		}
+
+		actual := indexTemplate.Schema()
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("expected: %v, actual: %v", expected, actual)
+		}
+	})
+
+	t.Run("Data stream index template", func(t *testing.T) {
+		expected := IndexTemplateSchema{
+			IndexPatterns: []string{"logs-app-*"},
+			DataStream: &DataStreamSchema{
+				Hidden:             true,
+				AllowCustomRouting: false,
+			},
+		}
+
+		indexTemplate := &IndexTemplate{
+			Patterns: []string{"logs-app-*"},
+			DataStream: &IndexTemplateDataStream{
+				Hidden: true,
+				Create: []string{"logs-app-default"},
+			},
+		}
+
+		actual := indexTemplate.Schema()
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("expected: %v, actual: %v", expected, actual)
+		}
+	})
+}
+
+func TestIndexTemplate_Matches(t *testing.T) {
+	indexTemplate := &IndexTemplate{Patterns: []string{"logs-*-prod", "metrics"}}
+
+	for name, expected := range map[string]bool{
+		"logs-app-prod": true,
+		"metrics":       true,
+		"logs-app-dev":  false,
+		"metrics-app":   false,
+	} {
+		if actual := indexTemplate.Matches(name); actual != expected {
+			t.Errorf("[%s]: actual %v\nwant %v", name, actual, expected)
+		}
+	}
+}
+
+func TestIndexTemplate_Overlaps(t *testing.T) {
+	cases := []struct {
+		a, b     []string
+		overlaps bool
+	}{
+		{[]string{"logs-*"}, []string{"logs-*"}, true},
+		{[]string{"logs-*"}, []string{"logs-app-*"}, true},
+		{[]string{"logs-*"}, []string{"*-app"}, true},
+		{[]string{"*"}, []string{"metrics"}, true},
+		{[]string{"logs-*-prod"}, []string{"logs-app-*"}, true},
+		{[]string{"logs-*"}, []string{"metrics-*"}, false},
+		{[]string{"logs-*-prod"}, []string{"logs-*-dev"}, false},
+		{[]string{"logs"}, []string{"logs-*"}, false},
+		{[]string{"metrics-*", "traces-*"}, []string{"logs-*", "traces-app"}, true},
+	}
+
+	for _, c := range cases {
+		a := &IndexTemplate{Patterns: c.a}
+		b := &IndexTemplate{Patterns: c.b}
+
+		if actual := a.Overlaps(b); actual != c.overlaps {
+			t.Errorf("%v overlaps %v: actual %v\nwant %v", c.a, c.b, actual, c.overlaps)
+		}
+
+		if actual := b.Overlaps(a); actual != c.overlaps {
+			t.Errorf("%v overlaps %v: actual %v\nwant %v", c.b, c.a, actual, c.overlaps)
+		}
+	}
 }
@@ -0,0 +1,76 @@
+package resource
+
+import "sort"
+
+const IngestPipelineKind = "ingest pipeline"
+
+type IngestPipeline struct {
+	Name        string           `yaml:"name"`
+	Description string           `yaml:"description"`
+	Processors  []map[string]any `yaml:"processors"`
+	OnFailure   []map[string]any `yaml:"on_failure"`
+	Version     *int             `yaml:"version"`
+}
+
+type IngestPipelineSchema struct {
+	Description string           `json:"description,omitempty"`
+	Processors  []map[string]any `json:"processors"`
+	OnFailure   []map[string]any `json:"on_failure,omitempty"`
+	Version     *int             `json:"version,omitempty"`
+}
+
+func (p *IngestPipeline) Schema() IngestPipelineSchema {
+	processors := p.Processors
+	if processors == nil {
+		processors = []map[string]any{}
+	}
+
+	return IngestPipelineSchema{
+		Description: p.Description,
+		Processors:  processors,
+		OnFailure:   p.OnFailure,
+		Version:     p.Version,
+	}
+}
+
+func (p *IngestPipeline) PipelineNames() []string {
+	found := map[string]bool{}
+
+	for _, processors := range [][]map[string]any{p.Processors, p.OnFailure} {
+		for _, processor := range processors {
+			collectPipelineNames(processor, found)
+		}
+	}
+
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func collectPipelineNames(value any, found map[string]bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		if options, ok := v["pipeline"].(map[string]any); ok {
+			if name, ok := options["name"].(string); ok && name != "" {
+				found[name] = true
+			}
+		}
+
+		for _, nested := range v {
+			collectPipelineNames(nested, found)
+		}
+	case []map[string]any:
+		for _, nested := range v {
+			collectPipelineNames(nested, found)
+		}
+	case []any:
+		for _, nested := range v {
+			collectPipelineNames(nested, found)
+		}
+	}
+}
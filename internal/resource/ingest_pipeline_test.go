@@ -0,0 +1,77 @@
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIngestPipeline_Schema(t *testing.T) {
+	t.Run("Ingest pipeline default schema", func(t *testing.T) {
+		expected := IngestPipelineSchema{Processors: []map[string]any{}}
+		actual := (&IngestPipeline{}).Schema()
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("expected: %v, actual: %v", expected, actual)
+		}
+	})
+
+	t.Run("Ingest pipeline with processors, on_failure and version", func(t *testing.T) {
+		version := 2
+
+		expected := IngestPipelineSchema{
+			Description: "Parse logs",
+			Processors: []map[string]any{
+				{"dissect": map[string]any{"field": "message", "pattern": "%{level} %{msg}"}},
+			},
+			OnFailure: []map[string]any{
+				{"set": map[string]any{"field": "error.message", "value": "{{ _ingest.on_failure_message }}"}},
+			},
+			Version: &version,
+		}
+
+		pipeline := &IngestPipeline{
+			Name:        "parse",
+			Description: "Parse logs",
+			Processors: []map[string]any{
+				{"dissect": map[string]any{"field": "message", "pattern": "%{level} %{msg}"}},
+			},
+			OnFailure: []map[string]any{
+				{"set": map[string]any{"field": "error.message", "value": "{{ _ingest.on_failure_message }}"}},
+			},
+			Version: &version,
+		}
+
+		actual := pipeline.Schema()
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("expected: %v, actual: %v", expected, actual)
+		}
+	})
+}
+
+func TestIngestPipeline_PipelineNames(t *testing.T) {
+	pipeline := &IngestPipeline{
+		Processors: []map[string]any{
+			{"pipeline": map[string]any{"name": "geoip"}},
+			{"foreach": map[string]any{
+				"field":     "events",
+				"processor": map[string]any{"pipeline": map[string]any{"name": "event"}},
+			}},
+			{"set": map[string]any{
+				"field":      "env",
+				"value":      "prod",
+				"on_failure": []any{map[string]any{"pipeline": map[string]any{"name": "geoip"}}},
+			}},
+		},
+		OnFailure: []map[string]any{
+			{"pipeline": map[string]any{"name": "failures"}},
+		},
+	}
+
+	expected := []string{"event", "failures", "geoip"}
+	actual := pipeline.PipelineNames()
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected: %v, actual: %v", expected, actual)
+	}
+}
@@ -0,0 +1,52 @@
+package resource
+
+type Rollover struct {
+	MaxAge              string `yaml:"max_age"`
+	MaxSize             string `yaml:"max_size"`
+	MaxPrimaryShardSize string `yaml:"max_primary_shard_size"`
+	MaxDocs             uint64 `yaml:"max_docs"`
+	MaxPrimaryShardDocs uint64 `yaml:"max_primary_shard_docs"`
+	MinAge              string `yaml:"min_age"`
+	MinSize             string `yaml:"min_size"`
+	MinPrimaryShardSize string `yaml:"min_primary_shard_size"`
+	MinDocs             uint64 `yaml:"min_docs"`
+	MinPrimaryShardDocs uint64 `yaml:"min_primary_shard_docs"`
+}
+
+func (r *Rollover) HasMaxCondition() bool {
+	return r.MaxAge != "" ||
+		r.MaxSize != "" ||
+		r.MaxPrimaryShardSize != "" ||
+		r.MaxDocs > 0 ||
+		r.MaxPrimaryShardDocs > 0
+}
+
+func (r *Rollover) Conditions() map[string]any {
+	conditions := map[string]any{}
+
+	for name, value := range map[string]string{
+		"max_age":                r.MaxAge,
+		"max_size":               r.MaxSize,
+		"max_primary_shard_size": r.MaxPrimaryShardSize,
+		"min_age":                r.MinAge,
+		"min_size":               r.MinSize,
+		"min_primary_shard_size": r.MinPrimaryShardSize,
+	} {
+		if value != "" {
+			conditions[name] = value
+		}
+	}
+
+	for name, value := range map[string]uint64{
+		"max_docs":               r.MaxDocs,
+		"max_primary_shard_docs": r.MaxPrimaryShardDocs,
+		"min_docs":               r.MinDocs,
+		"min_primary_shard_docs": r.MinPrimaryShardDocs,
+	} {
+		if value > 0 {
+			conditions[name] = value
+		}
+	}
+
+	return conditions
+}
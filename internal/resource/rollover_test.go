@@ -0,0 +1,65 @@
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRollover_HasMaxCondition(t *testing.T) {
+	t.Run("Rollover without conditions", func(t *testing.T) {
+		if (&Rollover{}).HasMaxCondition() {
+			t.Errorf("rollover without conditions should not have max condition")
+		}
+	})
+
+	t.Run("Rollover with min conditions only", func(t *testing.T) {
+		rollover := &Rollover{
+			MinAge:  "1d",
+			MinDocs: 10,
+		}
+
+		if rollover.HasMaxCondition() {
+			t.Errorf("rollover with min conditions only should not have max condition")
+		}
+	})
+
+	t.Run("Rollover with max docs condition", func(t *testing.T) {
+		if !(&Rollover{MaxDocs: 1000}).HasMaxCondition() {
+			t.Errorf("rollover with max_docs should have max condition")
+		}
+	})
+}
+
+func TestRollover_Conditions(t *testing.T) {
+	expected := map[string]any{
+		"max_age":                "7d",
+		"max_size":               "100gb",
+		"max_primary_shard_size": "50gb",
+		"max_docs":               uint64(1000),
+		"max_primary_shard_docs": uint64(500),
+		"min_age":                "1d",
+		"min_size":               "1gb",
+		"min_primary_shard_size": "1gb",
+		"min_docs":               uint64(10),
+		"min_primary_shard_docs": uint64(5),
+	}
+
+	rollover := &Rollover{
+		MaxAge:              "7d",
+		MaxSize:             "100gb",
+		MaxPrimaryShardSize: "50gb",
+		MaxDocs:             1000,
+		MaxPrimaryShardDocs: 500,
+		MinAge:              "1d",
+		MinSize:             "1gb",
+		MinPrimaryShardSize: "1gb",
+		MinDocs:             10,
+		MinPrimaryShardDocs: 5,
+	}
+
+	actual := rollover.Conditions()
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("actual %v\nwant %v", actual, expected)
+	}
+}
@@ -0,0 +1,117 @@
+package resource
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Settings map[string]any
+
+func (s Settings) Normalize() Settings {
+	if len(s) == 0 {
+		return nil
+	}
+
+	flat := map[string]string{}
+	flattenSettings("", s, flat)
+
+	normalized := Settings{}
+	for key, value := range flat {
+		if !strings.HasPrefix(key, "index.") {
+			key = "index." + key
+		}
+
+		expandSetting(normalized, strings.Split(key, "."), value)
+	}
+
+	return normalized
+}
+
+func (s Settings) Has(key string) bool {
+	flat := map[string]string{}
+	flattenSettings("", s, flat)
+
+	_, ok := flat[key]
+	if !ok && strings.HasPrefix(key, "index.") {
+		_, ok = flat[strings.TrimPrefix(key, "index.")]
+	}
+
+	return ok
+}
+
+func (s Settings) With(key string, value any) Settings {
+	settings := copySettings(s)
+	path := strings.Split(key, ".")
+
+	nested := map[string]any(settings)
+	for _, name := range path[:len(path)-1] {
+		next, ok := nested[name].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			nested[name] = next
+		}
+
+		nested = next
+	}
+
+	nested[path[len(path)-1]] = value
+
+	return settings
+}
+
+func copySettings(settings map[string]any) Settings {
+	copied := Settings{}
+
+	for key, value := range settings {
+		switch v := value.(type) {
+		case map[string]any:
+			copied[key] = map[string]any(copySettings(v))
+		case Settings:
+			copied[key] = map[string]any(copySettings(v))
+		default:
+			copied[key] = v
+		}
+	}
+
+	return copied
+}
+
+func flattenSettings(prefix string, settings map[string]any, flat map[string]string) {
+	for key, value := range settings {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case map[string]any:
+			flattenSettings(key, v, flat)
+		case Settings:
+			flattenSettings(key, v, flat)
+		case []any:
+			values := make([]string, 0, len(v))
+			for _, item := range v {
+				values = append(values, fmt.Sprintf("%v", item))
+			}
+			sort.Strings(values)
+			flat[key] = strings.Join(values, ",")
+		default:
+			flat[key] = fmt.Sprintf("%v", v)
+		}
+	}
+}
+
+func expandSetting(settings map[string]any, path []string, value string) {
+	if len(path) == 1 {
+		settings[path[0]] = value
+		return
+	}
+
+	nested, ok := settings[path[0]].(map[string]any)
+	if !ok {
+		nested = map[string]any{}
+		settings[path[0]] = nested
+	}
+
+	expandSetting(nested, path[1:], value)
+}
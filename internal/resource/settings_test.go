@@ -0,0 +1,114 @@
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSettings_Normalize(t *testing.T) {
+	t.Run("Empty settings", func(t *testing.T) {
+		if actual := (Settings{}).Normalize(); actual != nil {
+			t.Errorf("actual %v\nwant nil", actual)
+		}
+	})
+
+	t.Run("Flat, nested and prefixed settings are normalized to the same shape", func(t *testing.T) {
+		expected := Settings{
+			"index": map[string]any{
+				"number_of_shards":   "1",
+				"number_of_replicas": "0",
+				"refresh_interval":   "30s",
+				"lifecycle": map[string]any{
+					"name": "policy",
+				},
+			},
+		}
+
+		for _, settings := range []Settings{
+			{
+				"number_of_shards":   1,
+				"number_of_replicas": 0,
+				"refresh_interval":   "30s",
+				"lifecycle.name":     "policy",
+			},
+			{
+				"index": map[string]any{
+					"number_of_shards":   "1",
+					"number_of_replicas": "0",
+					"refresh_interval":   "30s",
+					"lifecycle": map[string]any{
+						"name": "policy",
+					},
+				},
+			},
+			{
+				"index.number_of_shards":   1,
+				"index.number_of_replicas": "0",
+				"index": map[string]any{
+					"refresh_interval": "30s",
+				},
+				"lifecycle": map[string]any{
+					"name": "policy",
+				},
+			},
+		} {
+			actual := settings.Normalize()
+
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("actual %v\nwant %v", actual, expected)
+			}
+		}
+	})
+}
+
+func TestSettings_Has(t *testing.T) {
+	for _, settings := range []Settings{
+		{"index.lifecycle.name": "policy"},
+		{"lifecycle.name": "policy"},
+		{"index": map[string]any{"lifecycle": map[string]any{"name": "policy"}}},
+	} {
+		if !settings.Has("index.lifecycle.name") {
+			t.Errorf("settings %v should have index.lifecycle.name", settings)
+		}
+	}
+
+	if (Settings{"index": map[string]any{"codec": "best_compression"}}).Has("index.lifecycle.name") {
+		t.Errorf("settings should not have index.lifecycle.name")
+	}
+}
+
+func TestSettings_With(t *testing.T) {
+	t.Run("Nil settings", func(t *testing.T) {
+		expected := Settings{"index": map[string]any{"lifecycle": map[string]any{"name": "policy"}}}
+		actual := Settings(nil).With("index.lifecycle.name", "policy")
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("actual %v\nwant %v", actual, expected)
+		}
+	})
+
+	t.Run("Existing nested settings are merged", func(t *testing.T) {
+		expected := Settings{
+			"number_of_replicas": 1,
+			"index": map[string]any{
+				"refresh_interval": "5s",
+				"lifecycle":        map[string]any{"name": "policy"},
+			},
+		}
+
+		settings := Settings{
+			"number_of_replicas": 1,
+			"index":              map[string]any{"refresh_interval": "5s"},
+		}
+
+		actual := settings.With("index.lifecycle.name", "policy")
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("actual %v\nwant %v", actual, expected)
+		}
+
+		if _, ok := settings["index"].(map[string]any)["lifecycle"]; ok {
+			t.Errorf("original settings should not be modified")
+		}
+	})
+}
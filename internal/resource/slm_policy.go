@@ -0,0 +1,100 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+)
+
+const SlmPolicyKind = "SLM policy"
+
+type SlmPolicyRetention struct {
+	ExpireAfter TimeValue `yaml:"expire_after"`
+	MinCount    *uint     `yaml:"min_count"`
+	MaxCount    *uint     `yaml:"max_count"`
+}
+
+type SlmPolicy struct {
+	Name         string              `yaml:"name"`
+	Schedule     string              `yaml:"schedule"`
+	SnapshotName string              `yaml:"snapshotName"`
+	Repository   string              `yaml:"repository"`
+	Indices      []string            `yaml:"indices"`
+	Retention    *SlmPolicyRetention `yaml:"retention"`
+}
+
+type SlmPolicyConfigSchema struct {
+	Indices []string `json:"indices,omitempty"`
+}
+
+type SlmPolicyRetentionSchema struct {
+	ExpireAfter string `json:"expire_after,omitempty"`
+	MinCount    *uint  `json:"min_count,omitempty"`
+	MaxCount    *uint  `json:"max_count,omitempty"`
+}
+
+type SlmPolicySchema struct {
+	Schedule   string                    `json:"schedule"`
+	Name       string                    `json:"name"`
+	Repository string                    `json:"repository"`
+	Config     *SlmPolicyConfigSchema    `json:"config,omitempty"`
+	Retention  *SlmPolicyRetentionSchema `json:"retention,omitempty"`
+}
+
+func (p *SlmPolicy) Schema() SlmPolicySchema {
+	schema := SlmPolicySchema{
+		Schedule:   p.Schedule,
+		Name:       p.SnapshotName,
+		Repository: p.Repository,
+	}
+
+	if len(p.Indices) > 0 {
+		schema.Config = &SlmPolicyConfigSchema{
+			Indices: p.Indices,
+		}
+	}
+
+	if p.Retention != nil {
+		schema.Retention = &SlmPolicyRetentionSchema{
+			MinCount: p.Retention.MinCount,
+			MaxCount: p.Retention.MaxCount,
+		}
+
+		if p.Retention.ExpireAfter != "" {
+			schema.Retention.ExpireAfter = p.Retention.ExpireAfter.String()
+		}
+	}
+
+	return schema
+}
+
+func (p *SlmPolicy) Validate() error {
+	if p.Schedule == "" {
+		return errors.New("schedule is required")
+	}
+
+	if err := ValidateCronExpression(p.Schedule); err != nil {
+		return err
+	}
+
+	if p.SnapshotName == "" {
+		return errors.New("snapshotName is required")
+	}
+
+	if p.Repository == "" {
+		return errors.New("repository is required")
+	}
+
+	if p.Retention == nil {
+		return nil
+	}
+
+	if _, err := p.Retention.ExpireAfter.Duration(); err != nil {
+		return fmt.Errorf("invalid retention expire_after: %w", err)
+	}
+
+	if p.Retention.MinCount != nil && p.Retention.MaxCount != nil && *p.Retention.MinCount > *p.Retention.MaxCount {
+		return fmt.Errorf("retention min_count %d is greater than max_count %d", *p.Retention.MinCount, *p.Retention.MaxCount)
+	}
+
+	return nil
+}
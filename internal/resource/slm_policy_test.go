@@ -0,0 +1,88 @@
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSlmPolicy_Schema(t *testing.T) {
+	t.Run("SLM policy without config and retention", func(t *testing.T) {
+		expected := SlmPolicySchema{
+			Schedule:   "0 30 1 * * ?",
+			Name:       "<nightly-snap-{now/d}>",
+			Repository: "backups",
+		}
+
+		policy := &SlmPolicy{
+			Name:         "nightly-snapshots",
+			Schedule:     "0 30 1 * * ?",
+			SnapshotName: "<nightly-snap-{now/d}>",
+			Repository:   "backups",
+		}
+
+		actual := policy.Schema()
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("expected: %v, actual: %v", expected, actual)
+		}
+	})
+
+	t.Run("SLM policy with indices and retention", func(t *testing.T) {
+		maxCount := uint(50)
+
+		expected := SlmPolicySchema{
+			Schedule:   "0 30 1 * * ?",
+			Name:       "<nightly-snap-{now/d}>",
+			Repository: "backups",
+			Config:     &SlmPolicyConfigSchema{Indices: []string{"logs-*"}},
+			Retention:  &SlmPolicyRetentionSchema{ExpireAfter: "30d", MaxCount: &maxCount},
+		}
+
+		policy := &SlmPolicy{
+			Name:         "nightly-snapshots",
+			Schedule:     "0 30 1 * * ?",
+			SnapshotName: "<nightly-snap-{now/d}>",
+			Repository:   "backups",
+			Indices:      []string{"logs-*"},
+			Retention:    &SlmPolicyRetention{ExpireAfter: "30", MaxCount: &maxCount},
+		}
+
+		actual := policy.Schema()
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("expected: %v, actual: %v", expected, actual)
+		}
+	})
+}
+
+func TestSlmPolicy_Validate(t *testing.T) {
+	minCount, maxCount := uint(10), uint(5)
+
+	for name, policy := range map[string]*SlmPolicy{
+		"missing schedule":      {SnapshotName: "snap", Repository: "backups"},
+		"invalid schedule":      {Schedule: "0 0 0 * * *", SnapshotName: "snap", Repository: "backups"},
+		"missing snapshot name": {Schedule: "0 30 1 * * ?", Repository: "backups"},
+		"missing repository":    {Schedule: "0 30 1 * * ?", SnapshotName: "snap"},
+		"invalid expire_after": {
+			Schedule:     "0 30 1 * * ?",
+			SnapshotName: "snap",
+			Repository:   "backups",
+			Retention:    &SlmPolicyRetention{ExpireAfter: "30 days"},
+		},
+		"min_count greater than max_count": {
+			Schedule:     "0 30 1 * * ?",
+			SnapshotName: "snap",
+			Repository:   "backups",
+			Retention:    &SlmPolicyRetention{MinCount: &minCount, MaxCount: &maxCount},
+		},
+	} {
+		if err := policy.Validate(); err == nil {
+			t.Errorf("[%s]: expected error", name)
+		}
+	}
+
+	valid := &SlmPolicy{Schedule: "0 30 1 * * ?", SnapshotName: "snap", Repository: "backups"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
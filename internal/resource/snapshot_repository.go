@@ -0,0 +1,82 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+)
+
+const SnapshotRepositoryKind = "snapshot repository"
+
+var snapshotRepositoryRequiredSettings = map[string][]string{
+	"fs":    {"location"},
+	"url":   {"url"},
+	"s3":    {},
+	"gcs":   {},
+	"azure": {},
+}
+
+type SnapshotRepository struct {
+	Name     string   `yaml:"name"`
+	Type     string   `yaml:"type"`
+	Settings Settings `yaml:"settings"`
+	Verify   *bool    `yaml:"verify"`
+}
+
+type SnapshotRepositorySchema struct {
+	Type     string   `json:"type"`
+	Settings Settings `json:"settings"`
+}
+
+func (r *SnapshotRepository) Schema() SnapshotRepositorySchema {
+	settings := r.Settings
+	if settings == nil {
+		settings = Settings{}
+	}
+
+	return SnapshotRepositorySchema{
+		Type:     r.Type,
+		Settings: settings,
+	}
+}
+
+func (r *SnapshotRepository) ShouldVerify() bool {
+	return r.Verify == nil || *r.Verify
+}
+
+func (r *SnapshotRepository) Validate() error {
+	if r.Type == "" {
+		return errors.New("type is required")
+	}
+
+	required, ok := snapshotRepositoryRequiredSettings[r.Type]
+	if !ok {
+		return fmt.Errorf("unsupported type [%s], expected one of fs, url, s3, gcs, azure", r.Type)
+	}
+
+	for _, setting := range required {
+		if !r.Settings.Has(setting) {
+			return fmt.Errorf("[%s] repository requires settings.%s", r.Type, setting)
+		}
+	}
+
+	return nil
+}
+
+func (s *SnapshotRepositorySchema) Normalize() *SnapshotRepositorySchema {
+	if s == nil {
+		return nil
+	}
+
+	flat := map[string]string{}
+	flattenSettings("", s.Settings, flat)
+
+	settings := Settings{}
+	for key, value := range flat {
+		settings[key] = value
+	}
+
+	return &SnapshotRepositorySchema{
+		Type:     s.Type,
+		Settings: settings,
+	}
+}
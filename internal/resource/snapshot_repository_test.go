@@ -0,0 +1,77 @@
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSnapshotRepository_Schema(t *testing.T) {
+	t.Run("Snapshot repository without settings", func(t *testing.T) {
+		expected := SnapshotRepositorySchema{Type: "s3", Settings: Settings{}}
+		actual := (&SnapshotRepository{Name: "backups", Type: "s3"}).Schema()
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("expected: %v, actual: %v", expected, actual)
+		}
+	})
+
+	t.Run("Snapshot repository with settings", func(t *testing.T) {
+		expected := SnapshotRepositorySchema{
+			Type:     "fs",
+			Settings: Settings{"location": "/mnt/backups", "compress": true},
+		}
+
+		repository := &SnapshotRepository{
+			Name:     "backups",
+			Type:     "fs",
+			Settings: Settings{"location": "/mnt/backups", "compress": true},
+		}
+
+		actual := repository.Schema()
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("expected: %v, actual: %v", expected, actual)
+		}
+	})
+}
+
+func TestSnapshotRepository_Validate(t *testing.T) {
+	for name, repository := range map[string]*SnapshotRepository{
+		"missing type":        {Name: "backups"},
+		"unsupported type":    {Name: "backups", Type: "hdfs"},
+		"fs without location": {Name: "backups", Type: "fs", Settings: Settings{"compress": true}},
+		"url without url":     {Name: "backups", Type: "url"},
+	} {
+		if err := repository.Validate(); err == nil {
+			t.Errorf("[%s]: expected error", name)
+		}
+	}
+
+	for name, repository := range map[string]*SnapshotRepository{
+		"fs":    {Name: "backups", Type: "fs", Settings: Settings{"location": "/mnt/backups"}},
+		"url":   {Name: "backups", Type: "url", Settings: Settings{"url": "https://example.com/backups"}},
+		"s3":    {Name: "backups", Type: "s3", Settings: Settings{"bucket": "backups", "client": "default"}},
+		"gcs":   {Name: "backups", Type: "gcs", Settings: Settings{"bucket": "backups"}},
+		"azure": {Name: "backups", Type: "azure"},
+	} {
+		if err := repository.Validate(); err != nil {
+			t.Errorf("[%s]: unexpected error %v", name, err)
+		}
+	}
+}
+
+func TestSnapshotRepositorySchema_Normalize(t *testing.T) {
+	expected := &SnapshotRepositorySchema{
+		Type:     "fs",
+		Settings: Settings{"location": "/mnt/backups", "compress": "true", "max_snapshot_bytes_per_sec": "40mb"},
+	}
+
+	actual := (&SnapshotRepositorySchema{
+		Type:     "fs",
+		Settings: Settings{"location": "/mnt/backups", "compress": true, "max_snapshot_bytes_per_sec": "40mb"},
+	}).Normalize()
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected: %v, actual: %v", expected, actual)
+	}
+}
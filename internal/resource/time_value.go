@@ -0,0 +1,63 @@
+package resource
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var timeValuePattern = regexp.MustCompile(`^(\d+)(d|h|m|s|ms|micros|nanos)?$`)
+
+var timeValueUnits = map[string]time.Duration{
+	"d":      24 * time.Hour,
+	"h":      time.Hour,
+	"m":      time.Minute,
+	"s":      time.Second,
+	"ms":     time.Millisecond,
+	"micros": time.Microsecond,
+	"nanos":  time.Nanosecond,
+}
+
+type TimeValue string
+
+func (t TimeValue) String() string {
+	if t == "" {
+		return "0ms"
+	}
+
+	matches := timeValuePattern.FindStringSubmatch(string(t))
+	if matches != nil && matches[2] == "" {
+		return string(t) + "d"
+	}
+
+	return string(t)
+}
+
+func (t TimeValue) Duration() (time.Duration, error) {
+	if t == "" {
+		return 0, nil
+	}
+
+	matches := timeValuePattern.FindStringSubmatch(string(t))
+	if matches == nil {
+		return 0, fmt.Errorf("invalid time value [%s], expected an integer followed by one of d, h, m, s, ms, micros, nanos", t)
+	}
+
+	value, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time value [%s]: %w", t, err)
+	}
+
+	unit := timeValueUnits["d"]
+	if matches[2] != "" {
+		unit = timeValueUnits[matches[2]]
+	}
+
+	if value > math.MaxInt64/int64(unit) {
+		return 0, fmt.Errorf("time value [%s] is too large", t)
+	}
+
+	return time.Duration(value) * unit, nil
+}
@@ -0,0 +1,59 @@
+package resource
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeValue_String(t *testing.T) {
+	cases := map[TimeValue]string{
+		"":       "0ms",
+		"30":     "30d",
+		"30d":    "30d",
+		"12h":    "12h",
+		"90m":    "90m",
+		"10ms":   "10ms",
+		"5nanos": "5nanos",
+	}
+
+	for value, expected := range cases {
+		if actual := value.String(); actual != expected {
+			t.Errorf("[%s]: actual %v\nwant %v", value, actual, expected)
+		}
+	}
+}
+
+func TestTimeValue_Duration(t *testing.T) {
+	t.Run("Valid time values", func(t *testing.T) {
+		cases := map[TimeValue]time.Duration{
+			"":         0,
+			"2":        48 * time.Hour,
+			"2d":       48 * time.Hour,
+			"12h":      12 * time.Hour,
+			"90m":      90 * time.Minute,
+			"30s":      30 * time.Second,
+			"100ms":    100 * time.Millisecond,
+			"10micros": 10 * time.Microsecond,
+			"7nanos":   7 * time.Nanosecond,
+		}
+
+		for value, expected := range cases {
+			actual, err := value.Duration()
+			if err != nil {
+				t.Fatalf("[%s]: %v", value, err)
+			}
+
+			if actual != expected {
+				t.Errorf("[%s]: actual %v\nwant %v", value, actual, expected)
+			}
+		}
+	})
+
+	t.Run("Invalid time values", func(t *testing.T) {
+		for _, value := range []TimeValue{"d", "1w", "-1d", "1.5h", "12 h", "999999999999d"} {
+			if _, err := value.Duration(); err == nil {
+				t.Errorf("[%s] should be invalid", value)
+			}
+		}
+	})
+}
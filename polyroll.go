@@ -1,46 +1,187 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/mihai-valentin/polyroll/internal"
+	"github.com/mihai-valentin/polyroll/internal/apply"
 	"github.com/mihai-valentin/polyroll/internal/elk"
+	"github.com/mihai-valentin/polyroll/internal/plan"
 	"log"
 	"os"
+	"strings"
 )
 
+const (
+	exitCodeError             = 1
+	exitCodePendingChanges    = 2
+	exitCodeConfigError       = 3
+	exitCodePartialFailure    = 4
+	exitCodeConnectionFailure = 5
+)
+
+var exitCodeErrorTypes = map[int]string{
+	exitCodeError:             "error",
+	exitCodeConfigError:       "config_error",
+	exitCodeConnectionFailure: "connection_error",
+}
+
+type jsonErrorEvent struct {
+	Event    string `json:"event"`
+	Type     string `json:"type"`
+	Message  string `json:"message"`
+	ExitCode int    `json:"exit_code"`
+}
+
+type options struct {
+	command      string
+	pathToConfig string
+	output       string
+}
+
 func main() {
-	if len(os.Args) < 2 || os.Args[1] == "" {
-		log.Fatalln("missing required argument 1 - path to config yaml file")
+	opts, err := parseArgs(os.Args[1:])
+	if err != nil {
+		exitWithError(opts.output, exitCodeError, "invalid arguments", err)
+	}
+
+	config, err := internal.ReadConfigFromFile(opts.pathToConfig)
+	if err != nil {
+		exitWithError(opts.output, exitCodeConfigError, "error reading config file", err)
 	}
 
-	config, err := internal.ReadConfigFromFile(os.Args[1])
+	ec, err := elk.NewElkClient(config.ElkHosts, elk.Options{
+		Auth:             config.Auth,
+		TLS:              config.TLS,
+		Timeout:          config.Timeout,
+		Retry:            config.Retry,
+		HostSelection:    config.HostSelection,
+		DeadHostCooldown: config.DeadHostCooldown,
+	})
 	if err != nil {
-		log.Fatalf("error reading config file: %s", err)
+		exitWithError(opts.output, exitCodeConfigError, "cannot create ELK client", err)
 	}
 
-	ec := elk.NewElkClient(config.ElkHost, config.AuthToken)
+	switch opts.command {
+	case "plan":
+		runPlan(ec, config, opts.output)
+	case "apply":
+		runApply(ec, config, opts.output)
+	default:
+		exitWithError(opts.output, exitCodeError, "invalid arguments",
+			fmt.Errorf("unknown command [%s], expected [plan] or [apply]", opts.command),
+		)
+	}
+}
 
-	for _, policy := range config.IlmPolicies {
-		log.Printf("Creating policy [%s]...\n", policy.Name)
+func parseArgs(args []string) (options, error) {
+	opts := options{output: "text"}
 
-		if err := ec.CreateOrUpdateIlmPolicy(policy); err != nil {
-			log.Printf("Cannot create ILM policy [%s]: %s\n", policy.Name, err)
-			continue
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--output" && i+1 < len(args):
+			i++
+			opts.output = args[i]
+		case strings.HasPrefix(args[i], "--output="):
+			opts.output = strings.TrimPrefix(args[i], "--output=")
+		default:
+			positional = append(positional, args[i])
 		}
+	}
 
-		log.Printf("Successfully created ILM policy [%s]\n", policy.Name)
+	if opts.output != "text" && opts.output != "json" {
+		return opts, fmt.Errorf("unknown output [%s], expected [text] or [json]", opts.output)
 	}
 
-	for _, indexTemplate := range config.IndexTemplates {
-		log.Printf("Creating index template [%s] with ILM policy [%s]...\n",
-			indexTemplate.Name,
-			indexTemplate.IlmPolicyName,
-		)
+	switch len(positional) {
+	case 1:
+		opts.command, opts.pathToConfig = "apply", positional[0]
+	case 2:
+		opts.command, opts.pathToConfig = positional[0], positional[1]
+	default:
+		return opts, errors.New("missing required argument - path to config yaml file")
+	}
+
+	return opts, nil
+}
 
-		if err := ec.CreateOrUpdateIndexTemplate(indexTemplate); err != nil {
-			log.Printf("Cannot create index template [%s]: %s\n", indexTemplate.Name, err)
-			continue
+func runPlan(ec *elk.Client, config *internal.Config, output string) {
+	p, err := plan.Build(ec, config)
+	if err != nil {
+		exitWithError(output, errorExitCode(err), "cannot build plan", err)
+	}
+
+	if output == "json" {
+		if err := p.PrintJSON(os.Stdout); err != nil {
+			log.Fatalf("cannot print plan: %s", err)
 		}
+	} else {
+		p.Print(os.Stdout)
+	}
 
-		log.Printf("Successfully created index template [%s]\n", indexTemplate.Name)
+	if p.HasPendingChanges() {
+		os.Exit(exitCodePendingChanges)
 	}
 }
+
+func runApply(ec *elk.Client, config *internal.Config, output string) {
+	resources, err := config.Resources()
+	if err != nil {
+		exitWithError(output, exitCodeConfigError, "cannot order resources", err)
+	}
+
+	var out apply.Output = apply.NewTextOutput(os.Stdout)
+	if output == "json" {
+		out = apply.NewJSONOutput(os.Stdout)
+	}
+
+	report := apply.Run(ec, resources, out)
+	if err := out.Report(report); err != nil {
+		log.Printf("cannot print apply report: %s", err)
+		os.Exit(exitCodeError)
+	}
+
+	if !report.HasFailures() {
+		return
+	}
+
+	for _, result := range report.Resources {
+		if errorExitCode(result.Err) == exitCodeConnectionFailure {
+			os.Exit(exitCodeConnectionFailure)
+		}
+	}
+
+	os.Exit(exitCodePartialFailure)
+}
+
+func errorExitCode(err error) int {
+	var connectionError *elk.ConnectionError
+	if errors.As(err, &connectionError) {
+		return exitCodeConnectionFailure
+	}
+
+	return exitCodeError
+}
+
+func exitWithError(output string, exitCode int, message string, err error) {
+	message = fmt.Sprintf("%s: %s", message, err)
+
+	log.Println(message)
+
+	if output == "json" {
+		event := jsonErrorEvent{
+			Event:    "error",
+			Type:     exitCodeErrorTypes[exitCode],
+			Message:  message,
+			ExitCode: exitCode,
+		}
+
+		if err := json.NewEncoder(os.Stdout).Encode(event); err != nil {
+			log.Printf("cannot print error event: %s", err)
+		}
+	}
+
+	os.Exit(exitCode)
+}